@@ -3,6 +3,7 @@ package main
 
 import (
 	"bufio"
+	"errors"
 	"fmt"
 	"io"
 	"os"
@@ -38,6 +39,12 @@ func getline(prompt bool) (string, error) {
 
 // printError represents function printing errors
 func printError(err error) {
+	var re *javalanche.RuntimeError
+	if errors.As(err, &re) {
+		// with where it happened
+		fmt.Println(red("Error"), re.StackTrace())
+		return
+	}
 	fmt.Println(red("Error"), err)
 }
 
@@ -46,6 +53,12 @@ func printResult(result javalanche.Value) {
 	fmt.Printf("%s %v\n", purple("Result:"), result)
 }
 
+// hasResult tells if a value is worth printing, statements
+// evaluate to null
+func hasResult(value javalanche.Value) bool {
+	return value != nil && value.Type() != javalanche.ValueTypeNull
+}
+
 // isExit checks if the input is the exit command
 func isExit(line string) bool {
 	return strings.EqualFold(line, "exit")
@@ -73,7 +86,7 @@ func main() {
 			value, err := ctx.EvalLine(line)
 			if err != nil {
 				printError(err)
-			} else if value != nil {
+			} else if hasResult(value) {
 				printResult(value)
 			}
 		}
@@ -113,7 +126,7 @@ func repl(ctx *javalanche.Javalanche) {
 			// error
 			printError(err)
 			prompt = true
-		case value != nil:
+		case hasResult(value):
 			// result
 			printResult(value)
 			prompt = true
@@ -133,7 +146,7 @@ func replNonTTY(ctx *javalanche.Javalanche) {
 		value, err := ctx.EvalLine(line)
 		if err != nil {
 			printError(err)
-		} else if value != nil {
+		} else if hasResult(value) {
 			printResult(value)
 		}
 	}
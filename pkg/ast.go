@@ -7,6 +7,23 @@ import (
 var (
 	errInvalidTypes = errors.New("invalid types")
 	errDivZero      = errors.New("division by zero")
+	errOverflow     = errors.New("integer overflow")
+	errTooLarge     = errors.New("too large")
+	errNotInteger   = errors.New("operand must be an integer")
+	errNegShift     = errors.New("negative shift count")
+	errNegRepeat    = errors.New("negative repeat count")
+	errIndexRange   = errors.New("index out of range")
+	errNull         = errors.New("null")
+	errNotFound     = errors.New("not found")
+	errConstant     = errors.New("can't be reassigned")
+	errCallDepth    = errors.New("maximum call depth exceeded")
+	errInterrupted  = errors.New("interrupted")
+	errPanic        = errors.New("internal error")
+	errZeroStep     = errors.New("step can't be zero")
+	errStepSlice    = errors.New("can't slice with a step")
+	errBreak        = errors.New("break outside of a loop")
+	errYield        = errors.New("yield outside of a generator")
+	errClosed       = errors.New("generator closed")
 )
 
 // AddValuer provides add interface
@@ -77,6 +94,102 @@ type ModValuer interface {
 	ModValue(Value) (Value, error)
 }
 
+// BitAndValuer provides bitwise & interface
+type BitAndValuer interface {
+	BitAndValue(Value) (Value, error)
+}
+
+// BitOrValuer provides bitwise | interface
+type BitOrValuer interface {
+	BitOrValue(Value) (Value, error)
+}
+
+// XorValuer provides xor interface
+type XorValuer interface {
+	XorValue(Value) (Value, error)
+}
+
+// BitNotValuer provides bitwise ~ interface
+type BitNotValuer interface {
+	BitNotValue() (Value, error)
+}
+
+// ShiftLeftValuer provides << interface
+type ShiftLeftValuer interface {
+	ShiftLeftValue(Value) (Value, error)
+}
+
+// ShiftRightValuer provides >> interface
+type ShiftRightValuer interface {
+	ShiftRightValue(Value) (Value, error)
+}
+
+// ContainsValuer provides `in` interface, called
+// on the right operand
+type ContainsValuer interface {
+	ContainsValue(Value) (Value, error)
+}
+
+// IndexValuer provides [index] interface
+type IndexValuer interface {
+	IndexValue(Value) (Value, error)
+}
+
+// SliceValuer provides [start:end] interface. Omitted
+// bounds are nil
+type SliceValuer interface {
+	SliceValue(start, end Value) (Value, error)
+}
+
+// RangeValuer provides `..` and `..<` interface
+type RangeValuer interface {
+	RangeValue(end Value, inclusive bool) (Value, error)
+}
+
+// StepValuer provides `by` interface
+type StepValuer interface {
+	StepValue(Value) (Value, error)
+}
+
+// IterValuer provides `for x in` interface
+type IterValuer interface {
+	IterValue() Iterator
+}
+
+// Iterator gives the values of an IterValuer one at a time.
+// Close must be called when stopping before the end
+type Iterator interface {
+	Next() (Value, bool, error)
+	Close()
+}
+
+// IteratorFunc is an Iterator with nothing to release.
+// It returns the next value, or false when there are no more
+type IteratorFunc func() (Value, bool, error)
+
+// Next returns the next value
+func (fn IteratorFunc) Next() (Value, bool, error) {
+	return fn()
+}
+
+// Close does nothing
+func (IteratorFunc) Close() {}
+
+// FieldValuer provides field access interface
+type FieldValuer interface {
+	FieldValue(name string) (Value, error)
+}
+
+// SetFieldValuer provides field assignment interface
+type SetFieldValuer interface {
+	SetFieldValue(name string, v Value) error
+}
+
+// CallValuer provides call interface
+type CallValuer interface {
+	CallValue(ctx *Javalanche, args ...Value) (Value, error)
+}
+
 // Value represents value interface
 type Value interface {
 	Type() ValueType
@@ -101,10 +214,62 @@ type ValueType int
 // ValueTypeFloat indicates the Value contains an Float
 // ValueTypeString inidcates the Value contains String
 // ValueTypeBool indicates the Value contains Bool
+// ValueTypeDecimal indicates the Value contains an exact Decimal
+// ValueTypeList indicates the Value contains a List
+// ValueTypeFunction indicates the Value can be called
+// ValueTypeRecord indicates the Value is an instance of a record type
+// ValueTypeObject indicates the Value is an instance of a class
+// ValueTypeRange indicates the Value is a range of integers
+// ValueTypeGenerator indicates the Value is a generator
+// ValueTypeError indicates the Value is an error
+// ValueTypeNull indicates the Value is null
 const (
 	ValueTypeUnknown ValueType = iota
 	ValueTypeInt
 	ValueTypeFloat
 	ValueTypeString
 	ValueTypeBool
+	ValueTypeDecimal
+	ValueTypeList
+	ValueTypeFunction
+	ValueTypeRecord
+	ValueTypeObject
+	ValueTypeRange
+	ValueTypeGenerator
+	ValueTypeError
+	ValueTypeNull
 )
+
+// String returns the name of the type as used in errors
+func (t ValueType) String() string {
+	switch t {
+	case ValueTypeInt:
+		return "int"
+	case ValueTypeFloat:
+		return "float"
+	case ValueTypeString:
+		return "string"
+	case ValueTypeBool:
+		return "bool"
+	case ValueTypeDecimal:
+		return "decimal"
+	case ValueTypeList:
+		return "list"
+	case ValueTypeFunction:
+		return "function"
+	case ValueTypeRecord:
+		return "record"
+	case ValueTypeObject:
+		return "object"
+	case ValueTypeRange:
+		return "range"
+	case ValueTypeGenerator:
+		return "generator"
+	case ValueTypeError:
+		return "error"
+	case ValueTypeNull:
+		return "null"
+	default:
+		return "unknown"
+	}
+}
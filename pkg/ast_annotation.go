@@ -0,0 +1,58 @@
+package javalanche
+
+import (
+	"fmt"
+)
+
+var (
+	_ Node           = (*AnnotatedVariable)(nil)
+	_ SetValuer      = (*AnnotatedVariable)(nil)
+	_ fmt.GoStringer = (*AnnotatedVariable)(nil)
+	_ fmt.Stringer   = (*AnnotatedVariable)(nil)
+)
+
+// AnnotatedVariable represents name: type, declaring the
+// type every value assigned to the variable must have
+type AnnotatedVariable struct {
+	Variable *Variable
+	Type     ValueType
+}
+
+func (v *AnnotatedVariable) GoString() string {
+	return fmt.Sprintf("&AnnotatedVariable{%#v, %q}", v.Variable, v.Type)
+}
+
+func (v *AnnotatedVariable) String() string {
+	return fmt.Sprintf("%s: %s", v.Variable, v.Type)
+}
+
+// Eval gets the value of the variable, failing if it
+// doesn't have the annotated type
+func (v *AnnotatedVariable) Eval(ctx *Javalanche) (Value, error) {
+	val, err := v.Variable.Eval(ctx)
+	switch {
+	case err != nil:
+		return nil, err
+	case val.Type() != v.Type:
+		return nil, typeErrorf("%q is %s, not %s", v.Variable.Name, val.Type(), v.Type)
+	default:
+		return val, nil
+	}
+}
+
+// SetValue declares the type of the variable, in the scope
+// it belongs to, and assigns it
+func (v *AnnotatedVariable) SetValue(ctx *Javalanche, val Value) error {
+	name := v.Variable.Name
+	if val.Type() != v.Type {
+		return typeErrorf("can't assign %s to %q of type %s", val.Type(), name, v.Type)
+	}
+
+	decl, _ := ctx.scopeOf(name)
+	if decl.consts[name] {
+		return fmt.Errorf("constant %q %w", name, errConstant)
+	}
+
+	decl.setType(name, v.Type)
+	return ctx.SetValue(name, val)
+}
@@ -0,0 +1,359 @@
+package javalanche
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+)
+
+var (
+	_ Value              = (*BigIntegerLiteral)(nil)
+	_ Node               = (*BigIntegerLiteral)(nil)
+	_ fmt.GoStringer     = (*BigIntegerLiteral)(nil)
+	_ fmt.Stringer       = (*BigIntegerLiteral)(nil)
+	_ AddValuer          = (*BigIntegerLiteral)(nil)
+	_ SubValuer          = (*BigIntegerLiteral)(nil)
+	_ MulValuer          = (*BigIntegerLiteral)(nil)
+	_ DivValuer          = (*BigIntegerLiteral)(nil)
+	_ NegValuer          = (*BigIntegerLiteral)(nil)
+	_ UpValuer           = (*BigIntegerLiteral)(nil)
+	_ ModValuer          = (*BigIntegerLiteral)(nil)
+	_ GreaterValuer      = (*BigIntegerLiteral)(nil)
+	_ GreaterEqualValuer = (*BigIntegerLiteral)(nil)
+	_ LesserValuer       = (*BigIntegerLiteral)(nil)
+	_ LesserEqualValuer  = (*BigIntegerLiteral)(nil)
+	_ BitAndValuer       = (*BigIntegerLiteral)(nil)
+	_ BitOrValuer        = (*BigIntegerLiteral)(nil)
+	_ XorValuer          = (*BigIntegerLiteral)(nil)
+	_ BitNotValuer       = (*BigIntegerLiteral)(nil)
+	_ ShiftLeftValuer    = (*BigIntegerLiteral)(nil)
+	_ ShiftRightValuer   = (*BigIntegerLiteral)(nil)
+	_ RangeValuer        = (*BigIntegerLiteral)(nil)
+)
+
+// maxBigIntBits limits the size of the integers shifts and
+// powers can create, as bigger ones could take all the memory
+const maxBigIntBits = 1 << 20
+
+// BigIntegerLiteral is an integer that doesn't fit in an int.
+// IntegerLiteral operations promote their results to it on
+// overflow, and it demotes itself back when the result fits again,
+// so scripts only ever see ValueTypeInt
+type BigIntegerLiteral struct {
+	Value *big.Int
+}
+
+// NewBigInteger returns the smallest integer Value able to hold n
+func NewBigInteger(n *big.Int) Value {
+	if n.IsInt64() {
+		if v := n.Int64(); v >= math.MinInt && v <= math.MaxInt {
+			return NewInteger(int(v))
+		}
+	}
+	return &BigIntegerLiteral{Value: n}
+}
+
+// NewBigIntegerString converts string value into a big integer
+// value, accepting the same forms as NewIntegerString
+func NewBigIntegerString(s string) (*BigIntegerLiteral, error) {
+	if digits, base, ok := integerDigits(s); ok {
+		if n, ok := new(big.Int).SetString(digits, base); ok {
+			return &BigIntegerLiteral{Value: n}, nil
+		}
+	}
+
+	return nil, &ErrInvalidValue{s}
+}
+
+// asBigInt returns the big.Int representation of an integer Value
+func asBigInt(v Value) (*big.Int, bool) {
+	switch n := v.(type) {
+	case *IntegerLiteral:
+		return big.NewInt(int64(n.Value)), true
+	case *BigIntegerLiteral:
+		return n.Value, true
+	default:
+		return nil, false
+	}
+}
+
+// isBigInteger tells if the value is an integer beyond
+// what an int holds
+func isBigInteger(v Value) bool {
+	_, ok := v.(*BigIntegerLiteral)
+	return ok
+}
+
+func (n *BigIntegerLiteral) GoString() string {
+	return fmt.Sprintf("NewBigIntegerString(%q)", n.Value.String())
+}
+
+func (n *BigIntegerLiteral) String() string {
+	return n.Value.String()
+}
+
+func (n *BigIntegerLiteral) Type() ValueType {
+	return ValueTypeInt
+}
+
+func (n *BigIntegerLiteral) AsFloat64() float64 {
+	f, _ := new(big.Float).SetInt(n.Value).Float64()
+	return f
+}
+
+func (n *BigIntegerLiteral) AsString() string {
+	return n.Value.String()
+}
+
+func (n *BigIntegerLiteral) AsBool() bool {
+	return n.Value.Sign() != 0
+}
+
+// Eval returns the literal itself, unless the interpreter
+// refuses integers beyond the int range
+func (n *BigIntegerLiteral) Eval(ctx *Javalanche) (Value, error) {
+	return ctx.checkInteger(n)
+}
+
+func (n *BigIntegerLiteral) Equal(v Value) bool {
+	switch right := v.(type) {
+	case *IntegerLiteral, *BigIntegerLiteral:
+		m, _ := asBigInt(right)
+		return n.Value.Cmp(m) == 0
+	case *FloatLiteral:
+		c, ok := n.cmpFloat(right.Value)
+		return ok && c == 0
+	case *DecimalLiteral:
+		return right.Equal(n)
+	default:
+		return false
+	}
+}
+
+// cmpFloat compares the integer against a float64, reporting
+// false if they are unordered because f is NaN
+func (n *BigIntegerLiteral) cmpFloat(f float64) (int, bool) {
+	if math.IsNaN(f) {
+		return 0, false
+	}
+	return new(big.Float).SetInt(n.Value).Cmp(big.NewFloat(f)), true
+}
+
+// cmp compares the integer against a numeric Value
+func (n *BigIntegerLiteral) cmp(v Value) (int, bool, error) {
+	switch right := v.(type) {
+	case *IntegerLiteral, *BigIntegerLiteral:
+		m, _ := asBigInt(right)
+		return n.Value.Cmp(m), true, nil
+	case *FloatLiteral:
+		c, ok := n.cmpFloat(right.Value)
+		return c, ok, nil
+	case *DecimalLiteral:
+		c, ok, err := right.cmp(n)
+		return -c, ok, err
+	default:
+		return 0, false, errInvalidTypes
+	}
+}
+
+func (n *BigIntegerLiteral) AddValue(v Value) (Value, error) {
+	switch right := v.(type) {
+	case *IntegerLiteral, *BigIntegerLiteral:
+		m, _ := asBigInt(right)
+		return NewBigInteger(new(big.Int).Add(n.Value, m)), nil
+	case *FloatLiteral:
+		return NewFloat(n.AsFloat64() + right.Value), nil
+	case *DecimalLiteral:
+		left, _ := right.promote(n)
+		return left.AddValue(right)
+	default:
+		return nil, errInvalidTypes
+	}
+}
+
+func (n *BigIntegerLiteral) SubValue(v Value) (Value, error) {
+	switch right := v.(type) {
+	case *IntegerLiteral, *BigIntegerLiteral:
+		m, _ := asBigInt(right)
+		return NewBigInteger(new(big.Int).Sub(n.Value, m)), nil
+	case *FloatLiteral:
+		return NewFloat(n.AsFloat64() - right.Value), nil
+	case *DecimalLiteral:
+		left, _ := right.promote(n)
+		return left.SubValue(right)
+	default:
+		return nil, errInvalidTypes
+	}
+}
+
+func (n *BigIntegerLiteral) MulValue(v Value) (Value, error) {
+	switch right := v.(type) {
+	case *IntegerLiteral, *BigIntegerLiteral:
+		m, _ := asBigInt(right)
+		return NewBigInteger(new(big.Int).Mul(n.Value, m)), nil
+	case *FloatLiteral:
+		return NewFloat(n.AsFloat64() * right.Value), nil
+	case *DecimalLiteral:
+		left, _ := right.promote(n)
+		return left.MulValue(right)
+	default:
+		return nil, errInvalidTypes
+	}
+}
+
+// DivValue divides as floats, like IntegerLiteral does
+func (n *BigIntegerLiteral) DivValue(v Value) (Value, error) {
+	switch right := v.(type) {
+	case *IntegerLiteral, *BigIntegerLiteral, *FloatLiteral:
+		if !right.AsBool() {
+			return nil, errDivZero
+		}
+		return NewFloat(n.AsFloat64() / right.AsFloat64()), nil
+	case *DecimalLiteral:
+		left, _ := right.promote(n)
+		return left.DivValue(right)
+	default:
+		return nil, errInvalidTypes
+	}
+}
+
+// ModValue is modulo operation, truncated like Go's %
+func (n *BigIntegerLiteral) ModValue(v Value) (Value, error) {
+	switch right := v.(type) {
+	case *IntegerLiteral, *BigIntegerLiteral:
+		m, _ := asBigInt(right)
+		if m.Sign() == 0 {
+			return nil, errDivZero
+		}
+		return NewBigInteger(new(big.Int).Rem(n.Value, m)), nil
+	default:
+		return nil, errInvalidTypes
+	}
+}
+
+func (n *BigIntegerLiteral) NegValue() (Value, error) {
+	return NewBigInteger(new(big.Int).Neg(n.Value)), nil
+}
+
+func (n *BigIntegerLiteral) UpValue(v Value) (Value, error) {
+	switch right := v.(type) {
+	case *IntegerLiteral:
+		return powBig(n.Value, right.Value)
+	case *BigIntegerLiteral, *FloatLiteral:
+		return NewFloat(math.Pow(n.AsFloat64(), right.AsFloat64())), nil
+	default:
+		return nil, errInvalidTypes
+	}
+}
+
+// powBig raises base to an int exponent, falling back to
+// float for negative exponents. Results are limited to
+// maxBigIntBits
+func powBig(base *big.Int, exponent int) (Value, error) {
+	if exponent < 0 {
+		f, _ := new(big.Float).SetInt(base).Float64()
+		return NewFloat(math.Pow(f, float64(exponent))), nil
+	}
+
+	// the result has at least (bits - 1) * exponent bits, unless
+	// base is 0, 1 or -1
+	if bits := base.BitLen(); bits > 1 && exponent > maxBigIntBits/(bits-1) {
+		return nil, fmt.Errorf("power %v: %w", exponent, errTooLarge)
+	}
+
+	res := new(big.Int).Exp(base, big.NewInt(int64(exponent)), nil)
+	return NewBigInteger(res), nil
+}
+
+func (n *BigIntegerLiteral) LesserValue(v Value) (Value, error) {
+	c, ok, err := n.cmp(v)
+	if err != nil {
+		return nil, err
+	}
+	return NewBoolean(ok && c < 0), nil
+}
+
+func (n *BigIntegerLiteral) GreaterValue(v Value) (Value, error) {
+	c, ok, err := n.cmp(v)
+	if err != nil {
+		return nil, err
+	}
+	return NewBoolean(ok && c > 0), nil
+}
+
+func (n *BigIntegerLiteral) LesserEqualValue(v Value) (Value, error) {
+	c, ok, err := n.cmp(v)
+	if err != nil {
+		return nil, err
+	}
+	return NewBoolean(ok && c <= 0), nil
+}
+
+func (n *BigIntegerLiteral) GreaterEqualValue(v Value) (Value, error) {
+	c, ok, err := n.cmp(v)
+	if err != nil {
+		return nil, err
+	}
+	return NewBoolean(ok && c >= 0), nil
+}
+
+// BitAndValue is bitwise and operation, using two's complement
+// for negative values like IntegerLiteral
+func (n *BigIntegerLiteral) BitAndValue(v Value) (Value, error) {
+	if m, ok := asBigInt(v); ok {
+		return NewBigInteger(new(big.Int).And(n.Value, m)), nil
+	}
+	return nil, errNotInteger
+}
+
+// BitOrValue is bitwise or operation
+func (n *BigIntegerLiteral) BitOrValue(v Value) (Value, error) {
+	if m, ok := asBigInt(v); ok {
+		return NewBigInteger(new(big.Int).Or(n.Value, m)), nil
+	}
+	return nil, errNotInteger
+}
+
+// XorValue is bitwise exclusive or operation
+func (n *BigIntegerLiteral) XorValue(v Value) (Value, error) {
+	if m, ok := asBigInt(v); ok {
+		return NewBigInteger(new(big.Int).Xor(n.Value, m)), nil
+	}
+	return nil, errNotInteger
+}
+
+// BitNotValue is bitwise complement operation
+func (n *BigIntegerLiteral) BitNotValue() (Value, error) {
+	return NewBigInteger(new(big.Int).Not(n.Value)), nil
+}
+
+// ShiftLeftValue shifts bits to the left, up to maxBigIntBits
+func (n *BigIntegerLiteral) ShiftLeftValue(v Value) (Value, error) {
+	count, err := shiftCount(v)
+	switch {
+	case err != nil:
+		return nil, err
+	case n.Value.Sign() == 0:
+		return NewInteger(0), nil
+	case uint(n.Value.BitLen())+count > maxBigIntBits:
+		return nil, fmt.Errorf("shift by %v: %w", count, errTooLarge)
+	}
+	return NewBigInteger(new(big.Int).Lsh(n.Value, count)), nil
+}
+
+// ShiftRightValue shifts bits to the right, keeping the sign
+func (n *BigIntegerLiteral) ShiftRightValue(v Value) (Value, error) {
+	count, err := shiftCount(v)
+	if err != nil {
+		return nil, err
+	}
+	return NewBigInteger(new(big.Int).Rsh(n.Value, count)), nil
+}
+
+// RangeValue fails, as ranges count ints
+func (n *BigIntegerLiteral) RangeValue(v Value, _ bool) (Value, error) {
+	if _, ok := asBigInt(v); !ok {
+		return nil, errNotInteger
+	}
+	return nil, fmt.Errorf("range from %v: %w", n, errTooLarge)
+}
@@ -13,6 +13,7 @@ var (
 	_ UpValuer         = (*BooleanLiteral)(nil)
 	_ AndValuer        = (*BooleanLiteral)(nil)
 	_ OrValuer         = (*BooleanLiteral)(nil)
+	_ XorValuer        = (*BooleanLiteral)(nil)
 	_ LogicalNotValuer = (*BooleanLiteral)(nil)
 )
 
@@ -115,6 +116,16 @@ func (n *BooleanLiteral) AndValue(v Value) (Value, error) {
 	}
 }
 
+// XorValue is logical exclusive or operation
+func (n *BooleanLiteral) XorValue(v Value) (Value, error) {
+	switch right := v.(type) {
+	case *BooleanLiteral:
+		return NewBoolean(n.Value != right.Value), nil
+	default:
+		return nil, errInvalidTypes
+	}
+}
+
 func (n *BooleanLiteral) LogicalNotValue() (Value, error) {
 	return NewBoolean(!n.Value), nil
 }
@@ -0,0 +1,342 @@
+package javalanche
+
+import (
+	"fmt"
+	"strings"
+)
+
+var (
+	_ Value          = (*Class)(nil)
+	_ Node           = (*Class)(nil)
+	_ CallValuer     = (*Class)(nil)
+	_ fmt.GoStringer = (*Class)(nil)
+	_ fmt.Stringer   = (*Class)(nil)
+
+	_ Value          = (*Object)(nil)
+	_ Node           = (*Object)(nil)
+	_ FieldValuer    = (*Object)(nil)
+	_ SetFieldValuer = (*Object)(nil)
+	_ fmt.GoStringer = (*Object)(nil)
+	_ fmt.Stringer   = (*Object)(nil)
+
+	_ Value          = (*Method)(nil)
+	_ CallValuer     = (*Method)(nil)
+	_ fmt.GoStringer = (*Method)(nil)
+	_ fmt.Stringer   = (*Method)(nil)
+
+	_ Value       = (*superValue)(nil)
+	_ FieldValuer = (*superValue)(nil)
+
+	_ Node           = (*ClassNode)(nil)
+	_ fmt.GoStringer = (*ClassNode)(nil)
+	_ fmt.Stringer   = (*ClassNode)(nil)
+)
+
+// constructorName is the method called when creating objects
+const constructorName = "init"
+
+// Class describes the fields and methods of its objects.
+// Calling it creates an object
+type Class struct {
+	Name    string
+	Parent  *Class
+	Fields  []string
+	Methods map[string]*Function
+
+	// defaults are evaluated for each new object
+	defaults []Node
+	scope    *scope
+}
+
+func (c *Class) GoString() string {
+	return fmt.Sprintf("&Class{%q}", c.Name)
+}
+
+func (c *Class) String() string {
+	return fmt.Sprintf("class %s", c.Name)
+}
+
+func (c *Class) Type() ValueType {
+	return ValueTypeFunction
+}
+
+func (c *Class) AsFloat64() float64 {
+	return 0
+}
+
+func (c *Class) AsString() string {
+	return c.String()
+}
+
+func (c *Class) AsBool() bool {
+	return true
+}
+
+func (c *Class) Eval(ctx *Javalanche) (Value, error) {
+	return c, nil
+}
+
+func (c *Class) Equal(v Value) bool {
+	return c == v
+}
+
+// CallValue creates an object with the default values of
+// its fields, and passes the arguments to its constructor
+func (c *Class) CallValue(ctx *Javalanche, args ...Value) (Value, error) {
+	o := &Object{Class: c, fields: make(map[string]Value)}
+	if err := c.initFields(ctx, o); err != nil {
+		return nil, err
+	}
+
+	init, defining := c.method(constructorName)
+	switch {
+	case init != nil:
+		m := &Method{This: o, Function: init, Class: defining}
+		if _, err := m.CallValue(ctx, args...); err != nil {
+			return nil, err
+		}
+	case len(args) > 0:
+		return nil, argumentsError(c.Name, 0, 0, len(args))
+	}
+	return o, nil
+}
+
+// initFields sets the fields of the parent classes first,
+// so redeclared fields take the last default
+func (c *Class) initFields(ctx *Javalanche, o *Object) error {
+	if c.Parent != nil {
+		if err := c.Parent.initFields(ctx, o); err != nil {
+			return err
+		}
+	}
+
+	saved := ctx.scope
+	ctx.scope = c.scope
+	defer func() { ctx.scope = saved }()
+
+	for i, name := range c.Fields {
+		v, err := c.defaults[i].Eval(ctx)
+		if err != nil {
+			return err
+		}
+		if _, ok := o.fields[name]; !ok {
+			o.names = append(o.names, name)
+		}
+		o.fields[name] = v
+	}
+	return nil
+}
+
+// method finds a method in the class or its parents, and
+// the class defining it
+func (c *Class) method(name string) (*Function, *Class) {
+	for ; c != nil; c = c.Parent {
+		if fn, ok := c.Methods[name]; ok {
+			return fn, c
+		}
+	}
+	return nil, nil
+}
+
+// Object is an instance of a Class. Objects are shared, and
+// only equal to themselves
+type Object struct {
+	Class *Class
+
+	names  []string
+	fields map[string]Value
+}
+
+func (o *Object) GoString() string {
+	return fmt.Sprintf("&Object{%#v, %q}", o.Class, o.names)
+}
+
+// String returns the object with its fields named, like
+// Point(x: 1, y: 2)
+func (o *Object) String() string {
+	s := make([]string, len(o.names))
+	for i, name := range o.names {
+		s[i] = fmt.Sprintf("%s: %s", name, literalString(o.fields[name]))
+	}
+	return fmt.Sprintf("%s(%s)", o.Class.Name, strings.Join(s, ", "))
+}
+
+func (o *Object) Type() ValueType {
+	return ValueTypeObject
+}
+
+func (o *Object) AsFloat64() float64 {
+	return 0
+}
+
+func (o *Object) AsString() string {
+	return o.String()
+}
+
+func (o *Object) AsBool() bool {
+	return true
+}
+
+func (o *Object) Eval(ctx *Javalanche) (Value, error) {
+	return o, nil
+}
+
+func (o *Object) Equal(v Value) bool {
+	return o == v
+}
+
+// FieldValue returns a field, or a method bound to the object
+func (o *Object) FieldValue(name string) (Value, error) {
+	if v, ok := o.fields[name]; ok {
+		return v, nil
+	}
+	if fn, c := o.Class.method(name); fn != nil {
+		return &Method{This: o, Function: fn, Class: c}, nil
+	}
+	return nil, fmt.Errorf("%s has no field %q", o.Class.Name, name)
+}
+
+// SetFieldValue changes a field declared by the class
+func (o *Object) SetFieldValue(name string, v Value) error {
+	if _, ok := o.fields[name]; !ok {
+		return fmt.Errorf("%s has no field %q", o.Class.Name, name)
+	}
+	o.fields[name] = v
+	return nil
+}
+
+// Method is a Function bound to an object, which it
+// gets as `this`. `super` gives the methods of the
+// parent of the class defining it
+type Method struct {
+	This     *Object
+	Function *Function
+	Class    *Class
+}
+
+func (m *Method) GoString() string {
+	return fmt.Sprintf("&Method{%#v, %#v}", m.This, m.Function)
+}
+
+func (m *Method) String() string {
+	return fmt.Sprintf("%s.%s", m.Class.Name, m.Function)
+}
+
+func (m *Method) Type() ValueType {
+	return ValueTypeFunction
+}
+
+func (m *Method) AsFloat64() float64 {
+	return 0
+}
+
+func (m *Method) AsString() string {
+	return m.String()
+}
+
+func (m *Method) AsBool() bool {
+	return true
+}
+
+func (m *Method) Equal(v Value) bool {
+	n, ok := v.(*Method)
+	return ok && n.This == m.This && n.Function == m.Function
+}
+
+func (m *Method) CallValue(ctx *Javalanche, args ...Value) (Value, error) {
+	vars := map[string]Value{"this": m.This}
+	if m.Class.Parent != nil {
+		vars["super"] = &superValue{this: m.This, class: m.Class.Parent}
+	}
+	return m.Function.call(ctx, vars, args)
+}
+
+// superValue gives access to the methods of a parent class
+type superValue struct {
+	this  *Object
+	class *Class
+}
+
+func (s *superValue) String() string {
+	return fmt.Sprintf("super %s", s.class.Name)
+}
+
+func (s *superValue) Type() ValueType {
+	return ValueTypeObject
+}
+
+func (s *superValue) AsFloat64() float64 {
+	return 0
+}
+
+func (s *superValue) AsString() string {
+	return s.String()
+}
+
+func (s *superValue) AsBool() bool {
+	return true
+}
+
+func (s *superValue) Equal(v Value) bool {
+	return false
+}
+
+func (s *superValue) FieldValue(name string) (Value, error) {
+	if fn, c := s.class.method(name); fn != nil {
+		return &Method{This: s.this, Function: fn, Class: c}, nil
+	}
+	return nil, fmt.Errorf("%s has no method %q", s.class.Name, name)
+}
+
+// ClassNode represents class Name(Parent) fields and methods end
+type ClassNode struct {
+	Name     string
+	Parent   string
+	Fields   []string
+	Defaults []Node
+	Methods  []*FunctionNode
+}
+
+func (n *ClassNode) GoString() string {
+	return fmt.Sprintf("&ClassNode{%q, %q, %q, %#v, %#v}",
+		n.Name, n.Parent, n.Fields, n.Defaults, n.Methods)
+}
+
+func (n *ClassNode) String() string {
+	if n.Parent != "" {
+		return fmt.Sprintf("class %s(%s) ... end", n.Name, n.Parent)
+	}
+	return fmt.Sprintf("class %s ... end", n.Name)
+}
+
+// Eval defines the class, which can only extend another class
+func (n *ClassNode) Eval(ctx *Javalanche) (Value, error) {
+	c := &Class{
+		Name:     n.Name,
+		Fields:   n.Fields,
+		Methods:  make(map[string]*Function),
+		defaults: n.Defaults,
+		scope:    ctx.scope,
+	}
+
+	if n.Parent != "" {
+		v, err := ctx.GetValue(n.Parent)
+		if err != nil {
+			return nil, err
+		}
+		parent, ok := v.(*Class)
+		if !ok {
+			return nil, fmt.Errorf("%s: %s is not a class", n.Name, n.Parent)
+		}
+		c.Parent = parent
+	}
+
+	for _, m := range n.Methods {
+		c.Methods[m.Name] = m.function(ctx)
+	}
+
+	if err := ctx.SetValue(n.Name, c); err != nil {
+		return nil, err
+	}
+	return NewNull(), nil
+}
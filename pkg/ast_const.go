@@ -0,0 +1,94 @@
+package javalanche
+
+import (
+	"fmt"
+)
+
+var (
+	_ Node           = (*ConstNode)(nil)
+	_ fmt.GoStringer = (*ConstNode)(nil)
+	_ fmt.Stringer   = (*ConstNode)(nil)
+)
+
+// ConstNode represents const name = value, or
+// const a, b = c, d
+type ConstNode struct {
+	Names  []string
+	Assign Node
+}
+
+func (n *ConstNode) GoString() string {
+	return fmt.Sprintf("&ConstNode{%q, %#v}", n.Names, n.Assign)
+}
+
+func (n *ConstNode) String() string {
+	return fmt.Sprintf("const %s", n.Assign)
+}
+
+// Eval assigns the values and then makes the variables
+// constant, in the scope they were assigned in
+func (n *ConstNode) Eval(ctx *Javalanche) (Value, error) {
+	if _, err := n.Assign.Eval(ctx); err != nil {
+		return nil, err
+	}
+
+	for _, name := range n.Names {
+		decl, _ := ctx.scopeOf(name)
+		decl.setConst(name)
+	}
+	return NewNull(), nil
+}
+
+// constNames returns the names assigned by a plain or
+// multiple assignment to variables
+func constNames(node Node) ([]string, bool) {
+	var targets []Node
+
+	switch n := node.(type) {
+	case *BinaryExpression:
+		if n.Op != "=" {
+			return nil, false
+		}
+		targets = []Node{n.Left}
+	case *AssignExpression:
+		targets = n.Targets
+	default:
+		return nil, false
+	}
+
+	names := make([]string, 0, len(targets))
+	for _, target := range targets {
+		assigned, ok := assignedNames(target)
+		if !ok {
+			return nil, false
+		}
+		names = append(names, assigned...)
+	}
+	return names, true
+}
+
+// assignedName returns the name of the variable a target
+// assigns, if it is one
+func assignedName(target Node) (string, bool) {
+	switch v := target.(type) {
+	case *Variable:
+		return v.Name, true
+	case *AnnotatedVariable:
+		return v.Variable.Name, true
+	default:
+		return "", false
+	}
+}
+
+// assignedNames returns the names of the variables a
+// target sets, including the fields it destructures
+func assignedNames(target Node) ([]string, bool) {
+	if f, ok := target.(*FieldsExpression); ok {
+		return f.Names, true
+	}
+	name, ok := assignedName(target)
+	if !ok {
+		return nil, false
+	}
+	return []string{name}, true
+}
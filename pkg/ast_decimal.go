@@ -0,0 +1,399 @@
+package javalanche
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+var (
+	_ Value              = (*DecimalLiteral)(nil)
+	_ Node               = (*DecimalLiteral)(nil)
+	_ fmt.GoStringer     = (*DecimalLiteral)(nil)
+	_ fmt.Stringer       = (*DecimalLiteral)(nil)
+	_ AddValuer          = (*DecimalLiteral)(nil)
+	_ SubValuer          = (*DecimalLiteral)(nil)
+	_ MulValuer          = (*DecimalLiteral)(nil)
+	_ DivValuer          = (*DecimalLiteral)(nil)
+	_ NegValuer          = (*DecimalLiteral)(nil)
+	_ GreaterValuer      = (*DecimalLiteral)(nil)
+	_ GreaterEqualValuer = (*DecimalLiteral)(nil)
+	_ LesserValuer       = (*DecimalLiteral)(nil)
+	_ LesserEqualValuer  = (*DecimalLiteral)(nil)
+)
+
+// RoundingMode tells how decimal results are rounded
+type RoundingMode int
+
+// RoundHalfEven rounds to nearest, ties to even
+// RoundHalfUp rounds to nearest, ties away from zero
+// RoundHalfDown rounds to nearest, ties towards zero
+// RoundUp rounds away from zero
+// RoundDown rounds towards zero
+// RoundCeiling rounds towards positive infinity
+// RoundFloor rounds towards negative infinity
+const (
+	RoundHalfEven RoundingMode = iota
+	RoundHalfUp
+	RoundHalfDown
+	RoundUp
+	RoundDown
+	RoundCeiling
+	RoundFloor
+)
+
+func (m RoundingMode) String() string {
+	switch m {
+	case RoundHalfEven:
+		return "HalfEven"
+	case RoundHalfUp:
+		return "HalfUp"
+	case RoundHalfDown:
+		return "HalfDown"
+	case RoundUp:
+		return "Up"
+	case RoundDown:
+		return "Down"
+	case RoundCeiling:
+		return "Ceiling"
+	case RoundFloor:
+		return "Floor"
+	default:
+		return "Unknown"
+	}
+}
+
+// DecimalContext controls the precision and rounding of decimal results
+type DecimalContext struct {
+	// Precision is the maximum number of digits kept after
+	// the decimal point
+	Precision int
+	// Rounding is how results are rounded to Precision
+	Rounding RoundingMode
+}
+
+// maxDecimalExponent limits the exponent of decimal literals
+const maxDecimalExponent = 1000
+
+// DefaultDecimalContext is used by decimals not bound to an interpreter
+var DefaultDecimalContext = DecimalContext{
+	Precision: 16,
+	Rounding:  RoundHalfEven,
+}
+
+// round reduces an unscaled value to at most Precision digits
+// after the decimal point
+func (c *DecimalContext) round(unscaled *big.Int, scale int) (*big.Int, int) {
+	if scale <= c.Precision {
+		return unscaled, scale
+	}
+
+	res := roundQuo(unscaled, pow10(scale-c.Precision), c.Rounding)
+	return res, c.Precision
+}
+
+// DecimalLiteral is an exact base 10 number, stored as an
+// unscaled integer and the number of digits after the point
+type DecimalLiteral struct {
+	Value *big.Int
+	Scale int
+
+	context *DecimalContext
+}
+
+// NewDecimal returns the decimal unscaled * 10^-scale
+func NewDecimal(unscaled *big.Int, scale int) *DecimalLiteral {
+	if scale < 0 {
+		unscaled = new(big.Int).Mul(unscaled, pow10(-scale))
+		scale = 0
+	}
+	return &DecimalLiteral{Value: unscaled, Scale: scale}
+}
+
+// NewDecimalString converts string value into decimal value.
+// The `d` suffix used by decimal literals is optional, and it
+// accepts exponents and `_` between digits like NewFloatString
+func NewDecimalString(s string) (*DecimalLiteral, error) {
+	digits, ok := stripDigitSeparators(strings.TrimSuffix(s, "d"))
+	if !ok {
+		return nil, &ErrInvalidValue{s}
+	}
+
+	// exponent
+	exp := 0
+	if i := strings.IndexAny(digits, "eE"); i >= 0 {
+		e, err := strconv.Atoi(digits[i+1:])
+		if err != nil || e > maxDecimalExponent || e < -maxDecimalExponent {
+			return nil, &ErrInvalidValue{s}
+		}
+		digits, exp = digits[:i], e
+	}
+
+	whole, frac, _ := strings.Cut(digits, ".")
+	if strings.Trim(frac, "0123456789") == "" && whole+frac != "" {
+		if n, ok := new(big.Int).SetString(whole+frac, 10); ok {
+			return NewDecimal(n, len(frac)-exp), nil
+		}
+	}
+
+	return nil, &ErrInvalidValue{s}
+}
+
+// pow10 returns 10^n
+func pow10(n int) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
+
+// roundQuo returns x/y rounded using the given mode
+func roundQuo(x, y *big.Int, mode RoundingMode) *big.Int {
+	q, r := new(big.Int).QuoRem(x, y, new(big.Int))
+	if r.Sign() == 0 {
+		return q
+	}
+
+	sign := x.Sign() * y.Sign()
+	half := new(big.Int).Lsh(new(big.Int).Abs(r), 1).Cmp(new(big.Int).Abs(y))
+
+	var away bool
+	switch mode {
+	case RoundHalfUp:
+		away = half >= 0
+	case RoundHalfDown:
+		away = half > 0
+	case RoundUp:
+		away = true
+	case RoundDown:
+		away = false
+	case RoundCeiling:
+		away = sign > 0
+	case RoundFloor:
+		away = sign < 0
+	default:
+		away = half > 0 || (half == 0 && q.Bit(0) == 1)
+	}
+
+	if away {
+		q.Add(q, big.NewInt(int64(sign)))
+	}
+	return q
+}
+
+// decimalContext returns the settings used for results
+func (n *DecimalLiteral) decimalContext() *DecimalContext {
+	if n.context != nil {
+		return n.context
+	}
+	return &DefaultDecimalContext
+}
+
+// newResult builds a decimal result sharing the settings of n
+func (n *DecimalLiteral) newResult(unscaled *big.Int, scale int) *DecimalLiteral {
+	c := n.decimalContext()
+	unscaled, scale = c.round(unscaled, scale)
+	return &DecimalLiteral{Value: unscaled, Scale: scale, context: n.context}
+}
+
+// promote converts integer and decimal Values into decimals
+// sharing the settings of n
+func (n *DecimalLiteral) promote(v Value) (*DecimalLiteral, bool) {
+	switch m := v.(type) {
+	case *DecimalLiteral:
+		return m, true
+	case *IntegerLiteral, *BigIntegerLiteral:
+		i, _ := asBigInt(m)
+		return &DecimalLiteral{Value: i, context: n.context}, true
+	default:
+		return nil, false
+	}
+}
+
+// rescaled returns the unscaled value at a scale not smaller
+// than the current one
+func (n *DecimalLiteral) rescaled(scale int) *big.Int {
+	if scale == n.Scale {
+		return n.Value
+	}
+	return new(big.Int).Mul(n.Value, pow10(scale-n.Scale))
+}
+
+// align returns both unscaled values at a common scale
+func (n *DecimalLiteral) align(m *DecimalLiteral) (*big.Int, *big.Int, int) {
+	scale := n.Scale
+	if m.Scale > scale {
+		scale = m.Scale
+	}
+	return n.rescaled(scale), m.rescaled(scale), scale
+}
+
+// rat returns the exact value as a big.Rat
+func (n *DecimalLiteral) rat() *big.Rat {
+	return new(big.Rat).SetFrac(n.Value, pow10(n.Scale))
+}
+
+func (n *DecimalLiteral) GoString() string {
+	return fmt.Sprintf("NewDecimalString(%q)", n.String())
+}
+
+func (n *DecimalLiteral) String() string {
+	s := new(big.Int).Abs(n.Value).String()
+	if n.Scale > 0 {
+		if len(s) <= n.Scale {
+			s = strings.Repeat("0", n.Scale-len(s)+1) + s
+		}
+		s = s[:len(s)-n.Scale] + "." + s[len(s)-n.Scale:]
+	}
+	if n.Value.Sign() < 0 {
+		s = "-" + s
+	}
+	return s
+}
+
+func (n *DecimalLiteral) Type() ValueType {
+	return ValueTypeDecimal
+}
+
+func (n *DecimalLiteral) AsFloat64() float64 {
+	f, _ := n.rat().Float64()
+	return f
+}
+
+func (n *DecimalLiteral) AsString() string {
+	return n.String()
+}
+
+func (n *DecimalLiteral) AsBool() bool {
+	return n.Value.Sign() != 0
+}
+
+// Eval binds the decimal to the precision and rounding
+// settings of the interpreter
+func (n *DecimalLiteral) Eval(ctx *Javalanche) (Value, error) {
+	if n.context == &ctx.Decimal {
+		return n, nil
+	}
+	return &DecimalLiteral{Value: n.Value, Scale: n.Scale, context: &ctx.Decimal}, nil
+}
+
+// cmp compares exactly against integers, decimals and floats,
+// reporting false if they are unordered
+func (n *DecimalLiteral) cmp(v Value) (int, bool, error) {
+	if m, ok := n.promote(v); ok {
+		a, b, _ := n.align(m)
+		return a.Cmp(b), true, nil
+	}
+
+	if m, ok := v.(*FloatLiteral); ok {
+		switch {
+		case math.IsNaN(m.Value):
+			return 0, false, nil
+		case math.IsInf(m.Value, 0):
+			return -int(math.Copysign(1, m.Value)), true, nil
+		default:
+			return n.rat().Cmp(new(big.Rat).SetFloat64(m.Value)), true, nil
+		}
+	}
+
+	return 0, false, errInvalidTypes
+}
+
+func (n *DecimalLiteral) Equal(v Value) bool {
+	c, ok, err := n.cmp(v)
+	return err == nil && ok && c == 0
+}
+
+func (n *DecimalLiteral) AddValue(v Value) (Value, error) {
+	if m, ok := n.promote(v); ok {
+		a, b, scale := n.align(m)
+		return n.newResult(new(big.Int).Add(a, b), scale), nil
+	}
+	return nil, errInvalidTypes
+}
+
+func (n *DecimalLiteral) SubValue(v Value) (Value, error) {
+	if m, ok := n.promote(v); ok {
+		a, b, scale := n.align(m)
+		return n.newResult(new(big.Int).Sub(a, b), scale), nil
+	}
+	return nil, errInvalidTypes
+}
+
+func (n *DecimalLiteral) MulValue(v Value) (Value, error) {
+	if m, ok := n.promote(v); ok {
+		res := new(big.Int).Mul(n.Value, m.Value)
+		return n.newResult(res, n.Scale+m.Scale), nil
+	}
+	return nil, errInvalidTypes
+}
+
+// DivValue divides rounding to the configured precision, and
+// keeps at least as many decimal places as the operands
+func (n *DecimalLiteral) DivValue(v Value) (Value, error) {
+	m, ok := n.promote(v)
+	switch {
+	case !ok:
+		return nil, errInvalidTypes
+	case m.Value.Sign() == 0:
+		return nil, errDivZero
+	}
+
+	c := n.decimalContext()
+	num := new(big.Int).Mul(n.Value, pow10(m.Scale+c.Precision))
+	den := new(big.Int).Mul(m.Value, pow10(n.Scale))
+	res := roundQuo(num, den, c.Rounding)
+
+	// drop trailing zeros beyond the scale of the operands
+	scale := c.Precision
+	minScale := n.Scale
+	if m.Scale > minScale {
+		minScale = m.Scale
+	}
+
+	ten := big.NewInt(10)
+	for scale > minScale {
+		q, r := new(big.Int).QuoRem(res, ten, new(big.Int))
+		if r.Sign() != 0 {
+			break
+		}
+		res, scale = q, scale-1
+	}
+
+	return n.newResult(res, scale), nil
+}
+
+func (n *DecimalLiteral) NegValue() (Value, error) {
+	return n.newResult(new(big.Int).Neg(n.Value), n.Scale), nil
+}
+
+func (n *DecimalLiteral) LesserValue(v Value) (Value, error) {
+	c, ok, err := n.cmp(v)
+	if err != nil {
+		return nil, err
+	}
+	return NewBoolean(ok && c < 0), nil
+}
+
+func (n *DecimalLiteral) GreaterValue(v Value) (Value, error) {
+	c, ok, err := n.cmp(v)
+	if err != nil {
+		return nil, err
+	}
+	return NewBoolean(ok && c > 0), nil
+}
+
+func (n *DecimalLiteral) LesserEqualValue(v Value) (Value, error) {
+	c, ok, err := n.cmp(v)
+	if err != nil {
+		return nil, err
+	}
+	return NewBoolean(ok && c <= 0), nil
+}
+
+func (n *DecimalLiteral) GreaterEqualValue(v Value) (Value, error) {
+	c, ok, err := n.cmp(v)
+	if err != nil {
+		return nil, err
+	}
+	return NewBoolean(ok && c >= 0), nil
+}
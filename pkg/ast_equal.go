@@ -0,0 +1,71 @@
+package javalanche
+
+// Equality
+//
+// The == and != operators apply the same coercion rules
+// whichever side each operand is on, so `a == b` is always
+// `b == a`:
+//
+//	left    right   compared as
+//	number  number  numbers, int, float and decimal mixed
+//	string  string  strings
+//	bool    bool    booleans
+//	string  number  numbers, if the string is a valid number
+//	                literal, otherwise not equal
+//	bool    other   never equal
+//
+// The === and !== operators never coerce. Both operands need
+// the same ValueType, so `1 === 1.0` and `"1" === 1` are false.
+
+// looseEqual implements the == operator
+func looseEqual(left, right Value) bool {
+	switch {
+	case left.Type() == ValueTypeString && isNumber(right):
+		left = coerceToNumber(left, right)
+	case isNumber(left) && right.Type() == ValueTypeString:
+		right = coerceToNumber(right, left)
+	}
+
+	return left.Equal(right)
+}
+
+// strictEqual implements the === operator
+func strictEqual(left, right Value) bool {
+	return left.Type() == right.Type() && left.Equal(right)
+}
+
+// isNumber tells if a Value is an int, float or decimal
+func isNumber(v Value) bool {
+	switch v.Type() {
+	case ValueTypeInt, ValueTypeFloat, ValueTypeDecimal:
+		return true
+	default:
+		return false
+	}
+}
+
+// coerceToNumber converts a string holding a number literal
+// into that number, or returns it unchanged. It's read as a
+// decimal when compared to one, so "0.1" isn't rounded
+// into a float first
+func coerceToNumber(v, other Value) Value {
+	s := v.AsString()
+
+	if other.Type() == ValueTypeDecimal {
+		if n, err := NewDecimalString(s); err == nil {
+			return n
+		}
+	}
+
+	if n, err := NewIntegerString(s); err == nil {
+		return n
+	}
+	if n, err := NewBigIntegerString(s); err == nil {
+		return n
+	}
+	if n, err := NewFloatString(s); err == nil {
+		return n
+	}
+
+	return v
+}
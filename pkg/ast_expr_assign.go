@@ -0,0 +1,147 @@
+package javalanche
+
+import (
+	"fmt"
+	"strings"
+)
+
+var (
+	_ Node           = (*AssignExpression)(nil)
+	_ fmt.GoStringer = (*AssignExpression)(nil)
+	_ fmt.Stringer   = (*AssignExpression)(nil)
+	_ Node           = (*FieldsExpression)(nil)
+	_ SetValuer      = (*FieldsExpression)(nil)
+	_ fmt.GoStringer = (*FieldsExpression)(nil)
+	_ fmt.Stringer   = (*FieldsExpression)(nil)
+)
+
+// AssignExpression represents a, b = b, a and unpacking
+// a list with a, b = pair
+type AssignExpression struct {
+	Targets []Node
+	Values  []Node
+	Pos     Position
+}
+
+func (n *AssignExpression) GoString() string {
+	return fmt.Sprintf("&AssignExpression{%s, %s}", goStringNodes(n.Targets), goStringNodes(n.Values))
+}
+
+func (n *AssignExpression) String() string {
+	return fmt.Sprintf("(%s = %s)", joinNodes(n.Targets), joinNodes(n.Values))
+}
+
+// Eval evaluates every value before setting any target,
+// so a, b = b, a swaps
+func (n *AssignExpression) Eval(ctx *Javalanche) (Value, error) {
+	values := make([]Value, 0, len(n.Values))
+	for _, node := range n.Values {
+		v, err := node.Eval(ctx)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, v)
+	}
+
+	if len(values) == 1 && len(n.Targets) > 1 {
+		items, err := unpackValue(values[0], len(n.Targets))
+		if err != nil {
+			return nil, err
+		}
+		values = items
+	}
+
+	if len(values) != len(n.Targets) {
+		return nil, assignMismatch(len(n.Targets), len(values))
+	}
+
+	for i, target := range n.Targets {
+		if err := setValue(ctx, target, values[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	return NewNull(), nil
+}
+
+// FieldsExpression represents {name, age} = person, which
+// assigns fields of a record or object to variables of
+// the same name
+type FieldsExpression struct {
+	Names []string
+	Pos   Position
+}
+
+func (n *FieldsExpression) GoString() string {
+	return fmt.Sprintf("&FieldsExpression{%q}", n.Names)
+}
+
+func (n *FieldsExpression) String() string {
+	return "{" + strings.Join(n.Names, ", ") + "}"
+}
+
+func (n *FieldsExpression) Eval(*Javalanche) (Value, error) {
+	return nil, fmt.Errorf("%s can only be assigned to", n)
+}
+
+// SetValue reads every field before setting any variable,
+// so a missing field assigns nothing
+func (n *FieldsExpression) SetValue(ctx *Javalanche, v Value) error {
+	r, ok := v.(FieldValuer)
+	if !ok {
+		return atPosition(typeErrorf("can't destructure %s, it has no fields", v.Type()), n.Pos)
+	}
+
+	values := make([]Value, len(n.Names))
+	for i, name := range n.Names {
+		field, err := r.FieldValue(name)
+		if err != nil {
+			return atPosition(err, n.Pos)
+		}
+		values[i] = field
+	}
+
+	for i, name := range n.Names {
+		if err := ctx.SetValue(name, values[i]); err != nil {
+			return atPosition(err, n.Pos)
+		}
+	}
+	return nil
+}
+
+// unpackValue returns the items of a list being assigned
+// to count variables
+func unpackValue(v Value, count int) ([]Value, error) {
+	list, ok := v.(*ListLiteral)
+	switch {
+	case !ok:
+		return nil, fmt.Errorf("can't unpack %s into %v variables", v.Type(), count)
+	case len(list.Items) != count:
+		return nil, fmt.Errorf("can't unpack %v items into %v variables", len(list.Items), count)
+	default:
+		return list.Items, nil
+	}
+}
+
+// assignMismatch reports different counts of variables and values
+func assignMismatch(targets, values int) error {
+	return fmt.Errorf("assignment mismatch: %v variables but %v values", targets, values)
+}
+
+// joinNodes lists nodes separated by commas
+func joinNodes(nodes []Node) string {
+	s := make([]string, len(nodes))
+	for i, node := range nodes {
+		s[i] = fmt.Sprint(node)
+	}
+	return strings.Join(s, ", ")
+}
+
+// goStringNodes formats nodes as a Go slice
+func goStringNodes(nodes []Node) string {
+	s := make([]string, len(nodes))
+	for i, node := range nodes {
+		s[i] = fmt.Sprintf("%#v", node)
+	}
+	return fmt.Sprintf("[]Node{%s}", strings.Join(s, ", "))
+}
@@ -1,6 +1,7 @@
 package javalanche
 
 import (
+	"errors"
 	"fmt"
 )
 
@@ -14,6 +15,7 @@ type BinaryExpression struct {
 	Left  Node
 	Op    string
 	Right Node
+	Pos   Position
 }
 
 func (n *BinaryExpression) GoString() string {
@@ -29,8 +31,12 @@ func (n *BinaryExpression) Eval(ctx *Javalanche) (Value, error) {
 	// the operation, that doesn't work for `=`
 	switch n.Op {
 	case "=":
-		return nil, n.evalAssign(ctx)
-
+		if err := n.evalAssign(ctx); err != nil {
+			return nil, err
+		}
+		return NewNull(), nil
+	case "??":
+		return n.evalCoalesce(ctx)
 	}
 
 	leftVal, err := n.Left.Eval(ctx)
@@ -43,12 +49,52 @@ func (n *BinaryExpression) Eval(ctx *Javalanche) (Value, error) {
 		return nil, err
 	}
 
+	val, err := n.apply(ctx, leftVal, rightVal)
+	return val, atPosition(err, n.Pos)
+}
+
+// apply applies the operator to the evaluated operands,
+// including the methods of objects overloading it
+func (n *BinaryExpression) apply(ctx *Javalanche, leftVal, rightVal Value) (Value, error) {
+	if val, ok, err := overloadBinary(ctx, n.Op, leftVal, rightVal); ok {
+		return val, err
+	}
+
+	val, err := n.evalOperator(leftVal, rightVal)
+	switch {
+	case errors.Is(err, errInvalidTypes):
+		// like the Checker
+		return nil, typeErrorf("operator %q can't be used on %s and %s", n.Op, leftVal.Type(), rightVal.Type())
+	case err != nil:
+		return nil, err
+	}
+
+	return ctx.checkInteger(val)
+}
+
+// evalOperator applies the operator to the already evaluated operands
+func (n *BinaryExpression) evalOperator(leftVal, rightVal Value) (Value, error) {
+	switch {
+	case n.Op == "==", n.Op == "!=", n.Op == "===", n.Op == "!==":
+		// null can be compared
+	case n.Op == "in" && !isNull(rightVal):
+		// null can be looked for
+	case isNull(leftVal), isNull(rightVal):
+		return nil, fmt.Errorf("operator %q can't be used on %w", n.Op, errNull)
+	}
+
 	switch n.Op {
 	case "==":
-		eq := leftVal.Equal(rightVal)
+		eq := looseEqual(leftVal, rightVal)
 		return NewBoolean(eq), nil
 	case "!=":
-		eq := leftVal.Equal(rightVal)
+		eq := looseEqual(leftVal, rightVal)
+		return NewBoolean(!eq), nil
+	case "===":
+		eq := strictEqual(leftVal, rightVal)
+		return NewBoolean(eq), nil
+	case "!==":
+		eq := strictEqual(leftVal, rightVal)
 		return NewBoolean(!eq), nil
 	case "&&", "and":
 		// left.AndValue(right)
@@ -103,11 +149,57 @@ func (n *BinaryExpression) Eval(ctx *Javalanche) (Value, error) {
 		if left, ok := leftVal.(ModValuer); ok {
 			return left.ModValue(rightVal)
 		}
-
+	case "&":
+		if left, ok := leftVal.(BitAndValuer); ok {
+			return left.BitAndValue(rightVal)
+		}
+	case "|":
+		if left, ok := leftVal.(BitOrValuer); ok {
+			return left.BitOrValue(rightVal)
+		}
+	case "xor":
+		if left, ok := leftVal.(XorValuer); ok {
+			return left.XorValue(rightVal)
+		}
+	case "<<":
+		if left, ok := leftVal.(ShiftLeftValuer); ok {
+			return left.ShiftLeftValue(rightVal)
+		}
+	case ">>":
+		if left, ok := leftVal.(ShiftRightValuer); ok {
+			return left.ShiftRightValue(rightVal)
+		}
+	case "..", "..<":
+		if left, ok := leftVal.(RangeValuer); ok {
+			return left.RangeValue(rightVal, n.Op == "..")
+		}
+	case "by":
+		if left, ok := leftVal.(StepValuer); ok {
+			return left.StepValue(rightVal)
+		}
+	case "in":
+		// right.ContainsValue(left)
+		if right, ok := rightVal.(ContainsValuer); ok {
+			return right.ContainsValue(leftVal)
+		}
+		return nil, typeErrorf("operator %q can't be used on %s", n.Op, rightVal)
 	}
 
-	err = fmt.Errorf("operator %q can't be used on %s", n.Op, leftVal)
-	return nil, err
+	return nil, typeErrorf("operator %q can't be used on %s", n.Op, leftVal)
+}
+
+// evalCoalesce only evaluates the right side if the left
+// is null or an undefined variable
+func (n *BinaryExpression) evalCoalesce(ctx *Javalanche) (Value, error) {
+	leftVal, err := evalNullable(ctx, n.Left)
+	switch {
+	case err != nil:
+		return nil, err
+	case !isNull(leftVal):
+		return leftVal, nil
+	default:
+		return n.Right.Eval(ctx)
+	}
 }
 
 func (n *BinaryExpression) evalAssign(ctx *Javalanche) error {
@@ -0,0 +1,53 @@
+package javalanche
+
+import (
+	"fmt"
+)
+
+var (
+	_ Node           = (*CallExpression)(nil)
+	_ fmt.GoStringer = (*CallExpression)(nil)
+	_ fmt.Stringer   = (*CallExpression)(nil)
+)
+
+// CallExpression represents callee(args...)
+type CallExpression struct {
+	Callee Node
+	Args   []Node
+	Pos    Position
+}
+
+func (n *CallExpression) GoString() string {
+	return fmt.Sprintf("&CallExpression{%#v, %s}", n.Callee, goStringNodes(n.Args))
+}
+
+func (n *CallExpression) String() string {
+	return fmt.Sprintf("%s(%s)", n.Callee, joinNodes(n.Args))
+}
+
+// Eval evaluates the callee, then the arguments in order,
+// and calls it
+func (n *CallExpression) Eval(ctx *Javalanche) (Value, error) {
+	callee, err := n.Callee.Eval(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	fn, ok := callee.(CallValuer)
+	if !ok {
+		return nil, atPosition(typeErrorf("%s is not a function", n.Callee), n.Pos)
+	}
+
+	args := make([]Value, 0, len(n.Args))
+	for _, node := range n.Args {
+		v, err := node.Eval(ctx)
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, v)
+	}
+
+	val, err := fn.CallValue(ctx, args...)
+	err = atPosition(err, n.Pos)
+	return val, traced(err, fmt.Sprintf("call %s", n.Callee), n.Pos)
+}
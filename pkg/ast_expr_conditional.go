@@ -0,0 +1,39 @@
+package javalanche
+
+import (
+	"fmt"
+)
+
+var (
+	_ Node           = (*ConditionalExpression)(nil)
+	_ fmt.GoStringer = (*ConditionalExpression)(nil)
+	_ fmt.Stringer   = (*ConditionalExpression)(nil)
+)
+
+// ConditionalExpression represents cond ? then : else
+type ConditionalExpression struct {
+	Condition Node
+	Then      Node
+	Else      Node
+}
+
+func (n *ConditionalExpression) GoString() string {
+	return fmt.Sprintf("&ConditionalExpression{%#v, %#v, %#v}", n.Condition, n.Then, n.Else)
+}
+
+func (n *ConditionalExpression) String() string {
+	return fmt.Sprintf("(%s ? %s : %s)", n.Condition, n.Then, n.Else)
+}
+
+// Eval evaluates the condition and then only the chosen branch
+func (n *ConditionalExpression) Eval(ctx *Javalanche) (Value, error) {
+	condVal, err := n.Condition.Eval(ctx)
+	switch {
+	case err != nil:
+		return nil, err
+	case condVal.AsBool():
+		return n.Then.Eval(ctx)
+	default:
+		return n.Else.Eval(ctx)
+	}
+}
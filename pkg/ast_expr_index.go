@@ -0,0 +1,201 @@
+package javalanche
+
+import (
+	"fmt"
+)
+
+var (
+	_ Node           = (*IndexExpression)(nil)
+	_ fmt.GoStringer = (*IndexExpression)(nil)
+	_ fmt.Stringer   = (*IndexExpression)(nil)
+	_ Node           = (*SliceExpression)(nil)
+	_ fmt.GoStringer = (*SliceExpression)(nil)
+	_ fmt.Stringer   = (*SliceExpression)(nil)
+)
+
+// IndexExpression represents expr[index] and expr?[index]
+type IndexExpression struct {
+	Expr     Node
+	Index    Node
+	Optional bool
+	Pos      Position
+}
+
+func (n *IndexExpression) GoString() string {
+	return fmt.Sprintf("&IndexExpression{%#v, %#v, %v}", n.Expr, n.Index, n.Optional)
+}
+
+func (n *IndexExpression) String() string {
+	return fmt.Sprintf("%s%s[%s]", n.Expr, accessOperator(n.Optional, ""), n.Index)
+}
+
+// Eval evaluates the expression and then the index.
+// Optional access to null gives null
+func (n *IndexExpression) Eval(ctx *Javalanche) (Value, error) {
+	val, skip, err := evalAccessed(ctx, n.Expr, n.Optional)
+	if err != nil || skip {
+		return val, err
+	}
+
+	index, err := n.Index.Eval(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	val, err = indexValue(val, index)
+	return val, atPosition(err, n.Pos)
+}
+
+// indexValue gets an item, or a slice when indexed by a range
+func indexValue(val, index Value) (Value, error) {
+	if r, ok := index.(*RangeLiteral); ok {
+		return sliceRange(val, r)
+	}
+
+	if v, ok := val.(IndexValuer); ok {
+		return v.IndexValue(index)
+	}
+
+	return nil, typeErrorf("%s can't be indexed", val)
+}
+
+// sliceRange slices with a range index, as in s[1..3]
+func sliceRange(val Value, r *RangeLiteral) (Value, error) {
+	v, ok := val.(SliceValuer)
+	if !ok {
+		return nil, typeErrorf("%s can't be sliced", val)
+	}
+
+	start, end, err := r.sliceBounds()
+	if err != nil {
+		return nil, err
+	}
+	return v.SliceValue(start, end)
+}
+
+// SliceExpression represents expr[start:end] and
+// expr?[start:end], where both bounds are optional
+type SliceExpression struct {
+	Expr     Node
+	Start    Node
+	End      Node
+	Optional bool
+	Pos      Position
+}
+
+func (n *SliceExpression) GoString() string {
+	return fmt.Sprintf("&SliceExpression{%#v, %#v, %#v, %v}", n.Expr, n.Start, n.End, n.Optional)
+}
+
+func (n *SliceExpression) String() string {
+	var start, end string
+	if n.Start != nil {
+		start = fmt.Sprint(n.Start)
+	}
+	if n.End != nil {
+		end = fmt.Sprint(n.End)
+	}
+	return fmt.Sprintf("%s%s[%s:%s]", n.Expr, accessOperator(n.Optional, ""), start, end)
+}
+
+// Eval evaluates the expression and then the bounds.
+// Optional access to null gives null
+func (n *SliceExpression) Eval(ctx *Javalanche) (Value, error) {
+	val, skip, err := evalAccessed(ctx, n.Expr, n.Optional)
+	if err != nil || skip {
+		return val, err
+	}
+
+	var start, end Value
+	if n.Start != nil {
+		if start, err = n.Start.Eval(ctx); err != nil {
+			return nil, err
+		}
+	}
+	if n.End != nil {
+		if end, err = n.End.Eval(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	v, ok := val.(SliceValuer)
+	if !ok {
+		return nil, atPosition(typeErrorf("%s can't be sliced", val), n.Pos)
+	}
+
+	val, err = v.SliceValue(start, end)
+	return val, atPosition(err, n.Pos)
+}
+
+// resolveIndex converts an index Value into a position in a
+// sequence of the given length. Negative indexes count from
+// the end
+func resolveIndex(v Value, length int) (int, error) {
+	i, ok := v.(*IntegerLiteral)
+	switch {
+	case !ok && isBigInteger(v):
+		return 0, errIndexRange
+	case !ok:
+		return 0, errNotInteger
+	}
+
+	index := i.Value
+	if index < 0 {
+		index += length
+	}
+	if index < 0 || index >= length {
+		return 0, errIndexRange
+	}
+	return index, nil
+}
+
+// resolveSlice converts slice bounds into positions in a
+// sequence of the given length. Negative bounds count from
+// the end, omitted ones mean the start or the end, and
+// bounds beyond the sequence are clamped
+func resolveSlice(start, end Value, length int) (int, int, error) {
+	bound := func(v Value, missing int) (int, error) {
+		if v == nil {
+			return missing, nil
+		}
+
+		if b, ok := v.(*BigIntegerLiteral); ok {
+			// beyond either end
+			if b.Value.Sign() < 0 {
+				return 0, nil
+			}
+			return length, nil
+		}
+
+		i, ok := v.(*IntegerLiteral)
+		if !ok {
+			return 0, errNotInteger
+		}
+
+		index := i.Value
+		if index < 0 {
+			index += length
+		}
+		switch {
+		case index < 0:
+			return 0, nil
+		case index > length:
+			return length, nil
+		default:
+			return index, nil
+		}
+	}
+
+	from, err := bound(start, 0)
+	if err != nil {
+		return 0, 0, err
+	}
+	until, err := bound(end, length)
+	if err != nil {
+		return 0, 0, err
+	}
+	if until < from {
+		until = from
+	}
+	return from, until, nil
+}
@@ -0,0 +1,103 @@
+package javalanche
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	_ Node           = (*MemberExpression)(nil)
+	_ SetValuer      = (*MemberExpression)(nil)
+	_ fmt.GoStringer = (*MemberExpression)(nil)
+	_ fmt.Stringer   = (*MemberExpression)(nil)
+)
+
+// MemberExpression represents expr.name or expr?.name
+type MemberExpression struct {
+	Expr     Node
+	Name     string
+	Optional bool
+	Pos      Position
+}
+
+func (n *MemberExpression) GoString() string {
+	return fmt.Sprintf("&MemberExpression{%#v, %q, %v}", n.Expr, n.Name, n.Optional)
+}
+
+func (n *MemberExpression) String() string {
+	return fmt.Sprintf("%s%s%s", n.Expr, accessOperator(n.Optional, "."), n.Name)
+}
+
+// Eval evaluates the expression and gets the field from it.
+// Optional access to null gives null
+func (n *MemberExpression) Eval(ctx *Javalanche) (Value, error) {
+	val, skip, err := evalAccessed(ctx, n.Expr, n.Optional)
+	if err != nil || skip {
+		return val, err
+	}
+
+	v, ok := val.(FieldValuer)
+	if !ok {
+		return nil, atPosition(typeErrorf("%s has no field %q", val, n.Name), n.Pos)
+	}
+
+	val, err = v.FieldValue(n.Name)
+	return val, atPosition(err, n.Pos)
+}
+
+// SetValue evaluates the expression and sets the field on it
+func (n *MemberExpression) SetValue(ctx *Javalanche, v Value) error {
+	if n.Optional {
+		return atPosition(fmt.Errorf("can't assign to %s", n), n.Pos)
+	}
+
+	val, err := n.Expr.Eval(ctx)
+	if err != nil {
+		return err
+	}
+
+	r, ok := val.(SetFieldValuer)
+	if !ok {
+		return atPosition(typeErrorf("%s has no field %q", val, n.Name), n.Pos)
+	}
+	return atPosition(r.SetFieldValue(n.Name, v), n.Pos)
+}
+
+// evalAccessed evaluates the operand of a field or index
+// access. When optional, null and undefined variables
+// skip the access and give null
+func evalAccessed(ctx *Javalanche, node Node, optional bool) (Value, bool, error) {
+	if !optional {
+		val, err := node.Eval(ctx)
+		return val, false, err
+	}
+
+	val, err := evalNullable(ctx, node)
+	switch {
+	case err != nil:
+		return nil, true, err
+	case isNull(val):
+		return NewNull(), true, nil
+	default:
+		return val, false, nil
+	}
+}
+
+// evalNullable evaluates a node, treating an undefined
+// variable as null
+func evalNullable(ctx *Javalanche, node Node) (Value, error) {
+	val, err := node.Eval(ctx)
+	if _, ok := node.(*Variable); ok && errors.Is(err, errNotFound) {
+		return NewNull(), nil
+	}
+	return val, err
+}
+
+// accessOperator returns the operator written before a field
+// or an index
+func accessOperator(optional bool, plain string) string {
+	if optional {
+		return "?" + plain
+	}
+	return plain
+}
@@ -13,6 +13,7 @@ var (
 type UnaryExpression struct {
 	Op   string
 	Expr Node
+	Pos  Position
 }
 
 func (n *UnaryExpression) GoString() string {
@@ -37,22 +38,44 @@ func (n *UnaryExpression) Eval(ctx *Javalanche) (Value, error) {
 		return nil, err
 	}
 
+	val, err = n.evalOperator(ctx, val)
+	return val, atPosition(err, n.Pos)
+}
+
+// evalOperator applies the operator to the already evaluated operand
+func (n *UnaryExpression) evalOperator(ctx *Javalanche, val Value) (Value, error) {
+	var err error
+
+	if isNull(val) {
+		return nil, fmt.Errorf("operator %q can't be used on %w", n.Op, errNull)
+	}
+
+	if v, ok, err := n.overload(ctx, val); ok {
+		return v, err
+	}
+
 	switch n.Op {
 	case "++":
 		if v, ok := val.(AddValuer); ok {
 			val, err = v.AddValue(NewInteger(1))
+			if err == nil {
+				val, err = ctx.checkInteger(val)
+			}
 			if err != nil {
 				return nil, err
 			}
-			return nil, setValue(ctx, n.Expr, val)
+			return NewNull(), setValue(ctx, n.Expr, val)
 		}
 	case "--":
 		if v, ok := val.(SubValuer); ok {
 			val, err = v.SubValue(NewInteger(1))
+			if err == nil {
+				val, err = ctx.checkInteger(val)
+			}
 			if err != nil {
 				return nil, err
 			}
-			return nil, setValue(ctx, n.Expr, val)
+			return NewNull(), setValue(ctx, n.Expr, val)
 		}
 	case "!":
 		if left, ok := val.(LogicalNotValuer); ok {
@@ -60,15 +83,36 @@ func (n *UnaryExpression) Eval(ctx *Javalanche) (Value, error) {
 		}
 	case "-":
 		if v, ok := val.(NegValuer); ok {
-			return v.NegValue()
+			val, err = v.NegValue()
+			if err != nil {
+				return nil, err
+			}
+			return ctx.checkInteger(val)
+		}
+	case "~":
+		if v, ok := val.(BitNotValuer); ok {
+			return v.BitNotValue()
 		}
 	case "+":
 		// NO-OP
 		return val, nil
 	}
 
-	err = fmt.Errorf("operator %q can't be used on %s", n.Op, val)
-	return nil, err
+	return nil, typeErrorf("operator %q can't be used on %s", n.Op, val)
+}
+
+// overload applies the methods of objects overloading
+// the operator. `++` and `--` use `+` and `-`
+func (n *UnaryExpression) overload(ctx *Javalanche, val Value) (Value, bool, error) {
+	if !isSuffixUnaryOperator(n.Op) {
+		return overloadUnary(ctx, n.Op, val)
+	}
+
+	v, ok, err := overloadBinary(ctx, n.Op[:1], val, NewInteger(1))
+	if ok && err == nil {
+		return NewNull(), true, setValue(ctx, n.Expr, v)
+	}
+	return nil, ok, err
 }
 
 func setValue(ctx *Javalanche, node Node, val Value) error {
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"math"
 	"strconv"
+	"strings"
 )
 
 var (
@@ -31,16 +32,26 @@ func NewFloat(n float64) *FloatLiteral {
 	return &FloatLiteral{Value: n}
 }
 
-// NewFloatString converts string value into float value
+// NewFloatString converts string value into float value.
+// It accepts exponents, a missing integer part like .5 and
+// `_` between digits
 func NewFloatString(s string) (*FloatLiteral, error) {
-	floatVal, err := strconv.ParseFloat(s, 64)
-	if err == nil {
-		return &FloatLiteral{Value: floatVal}, nil
+	if digits, ok := stripDigitSeparators(s); ok && isDecimalFloat(digits) {
+		floatVal, err := strconv.ParseFloat(digits, 64)
+		if err == nil {
+			return &FloatLiteral{Value: floatVal}, nil
+		}
 	}
 
 	return nil, &ErrInvalidValue{s}
 }
 
+// isDecimalFloat rejects the hexadecimal, infinity and NaN
+// forms strconv.ParseFloat would accept
+func isDecimalFloat(s string) bool {
+	return strings.Trim(s, "0123456789.eE+-") == ""
+}
+
 func (n *FloatLiteral) GoString() string {
 	return fmt.Sprintf("NewFloat(%f)", n.Value)
 }
@@ -75,6 +86,10 @@ func (n *FloatLiteral) Equal(v Value) bool {
 		return n.Value == right.Value
 	case *IntegerLiteral:
 		return n.Value == float64(right.Value)
+	case *BigIntegerLiteral:
+		return right.Equal(n)
+	case *DecimalLiteral:
+		return right.Equal(n)
 	default:
 		return false
 	}
@@ -88,6 +103,9 @@ func (n *FloatLiteral) AddValue(v Value) (Value, error) {
 	case *IntegerLiteral:
 		res := n.Value + float64(right.Value)
 		return NewFloat(res), nil
+	case *BigIntegerLiteral:
+		res := n.Value + right.AsFloat64()
+		return NewFloat(res), nil
 	default:
 		return nil, errInvalidTypes
 	}
@@ -101,6 +119,9 @@ func (n *FloatLiteral) SubValue(v Value) (Value, error) {
 	case *IntegerLiteral:
 		res := (n.Value) - (float64)(right.Value)
 		return NewFloat(res), nil
+	case *BigIntegerLiteral:
+		res := n.Value - right.AsFloat64()
+		return NewFloat(res), nil
 	default:
 		return nil, errInvalidTypes
 	}
@@ -122,6 +143,9 @@ func (n *FloatLiteral) DivValue(v Value) (Value, error) {
 		// float / int -> float
 		res := n.Value / (float64)(right.Value)
 		return NewFloat(res), nil
+	case *BigIntegerLiteral:
+		res := n.Value / right.AsFloat64()
+		return NewFloat(res), nil
 	default:
 		return nil, errInvalidTypes
 	}
@@ -137,6 +161,9 @@ func (n *FloatLiteral) MulValue(v Value) (Value, error) {
 		res := n.Value * (float64)(right.Value)
 		// 3.5 * 1.0 -> 3.5
 		return NewFloat(res), nil
+	case *BigIntegerLiteral:
+		res := n.Value * right.AsFloat64()
+		return NewFloat(res), nil
 	default:
 		return nil, errInvalidTypes
 	}
@@ -154,6 +181,9 @@ func (n *FloatLiteral) UpValue(v Value) (Value, error) {
 	case *IntegerLiteral:
 		res := math.Pow(n.Value, (float64)(right.Value))
 		return NewFloat(res), nil
+	case *BigIntegerLiteral:
+		res := math.Pow(n.Value, right.AsFloat64())
+		return NewFloat(res), nil
 	default:
 		return nil, errInvalidTypes
 	}
@@ -167,6 +197,12 @@ func (n *FloatLiteral) LesserValue(v Value) (Value, error) {
 	case *IntegerLiteral:
 		res := (n.Value < (float64)(right.Value))
 		return NewBoolean(res), nil
+	case *BigIntegerLiteral:
+		c, ok := right.cmpFloat(n.Value)
+		return NewBoolean(ok && c > 0), nil
+	case *DecimalLiteral:
+		c, ok, _ := right.cmp(n)
+		return NewBoolean(ok && c > 0), nil
 	default:
 		return nil, errInvalidTypes
 	}
@@ -180,6 +216,12 @@ func (n *FloatLiteral) GreaterValue(v Value) (Value, error) {
 	case *IntegerLiteral:
 		res := (n.Value > (float64)(right.Value))
 		return NewBoolean(res), nil
+	case *BigIntegerLiteral:
+		c, ok := right.cmpFloat(n.Value)
+		return NewBoolean(ok && c < 0), nil
+	case *DecimalLiteral:
+		c, ok, _ := right.cmp(n)
+		return NewBoolean(ok && c < 0), nil
 	default:
 		return nil, errInvalidTypes
 	}
@@ -193,6 +235,12 @@ func (n *FloatLiteral) GreaterEqualValue(v Value) (Value, error) {
 	case *IntegerLiteral:
 		res := (n.Value >= (float64)(right.Value))
 		return NewBoolean(res), nil
+	case *BigIntegerLiteral:
+		c, ok := right.cmpFloat(n.Value)
+		return NewBoolean(ok && c <= 0), nil
+	case *DecimalLiteral:
+		c, ok, _ := right.cmp(n)
+		return NewBoolean(ok && c <= 0), nil
 	default:
 		return nil, errInvalidTypes
 	}
@@ -206,6 +254,12 @@ func (n *FloatLiteral) LesserEqualValue(v Value) (Value, error) {
 	case *IntegerLiteral:
 		res := (n.Value <= (float64)(right.Value))
 		return NewBoolean(res), nil
+	case *BigIntegerLiteral:
+		c, ok := right.cmpFloat(n.Value)
+		return NewBoolean(ok && c >= 0), nil
+	case *DecimalLiteral:
+		c, ok, _ := right.cmp(n)
+		return NewBoolean(ok && c >= 0), nil
 	default:
 		return nil, errInvalidTypes
 	}
@@ -0,0 +1,232 @@
+package javalanche
+
+import (
+	"fmt"
+	"strings"
+)
+
+var (
+	_ Value          = (*Function)(nil)
+	_ Node           = (*Function)(nil)
+	_ CallValuer     = (*Function)(nil)
+	_ fmt.GoStringer = (*Function)(nil)
+	_ fmt.Stringer   = (*Function)(nil)
+
+	_ Node           = (*FunctionNode)(nil)
+	_ fmt.GoStringer = (*FunctionNode)(nil)
+	_ fmt.Stringer   = (*FunctionNode)(nil)
+
+	_ Node           = (*ResultAnnotation)(nil)
+	_ fmt.GoStringer = (*ResultAnnotation)(nil)
+	_ fmt.Stringer   = (*ResultAnnotation)(nil)
+)
+
+// Function is a function defined by a script. It runs
+// in a new scope inside the one it was defined in.
+// Calling a Generator function returns a *Generator
+// instead of running it. Types holds the annotated types of
+// the parameters, if any, and Result the one of the value
+// returned, or ValueTypeUnknown
+type Function struct {
+	Name      string
+	Params    []string
+	Types     []ValueType
+	Result    ValueType
+	Body      BodyNode
+	Generator bool
+
+	scope *scope
+}
+
+func (fn *Function) GoString() string {
+	return fmt.Sprintf("&Function{%q, %q, %#v}", fn.Name, fn.Params, fn.Body)
+}
+
+func (fn *Function) String() string {
+	return signature(fn.Name, fn.Params, fn.Types, fn.Result)
+}
+
+func (fn *Function) Type() ValueType {
+	return ValueTypeFunction
+}
+
+func (fn *Function) AsFloat64() float64 {
+	return 0
+}
+
+func (fn *Function) AsString() string {
+	return fn.String()
+}
+
+func (fn *Function) AsBool() bool {
+	return true
+}
+
+func (fn *Function) Eval(ctx *Javalanche) (Value, error) {
+	return fn, nil
+}
+
+func (fn *Function) Equal(v Value) bool {
+	return fn == v
+}
+
+// CallValue runs the body with the parameters set, and
+// returns the value of its last statement
+func (fn *Function) CallValue(ctx *Javalanche, args ...Value) (Value, error) {
+	return fn.call(ctx, make(map[string]Value), args)
+}
+
+// call runs the body in a scope with the given variables
+// and the parameters
+func (fn *Function) call(ctx *Javalanche, vars map[string]Value, args []Value) (Value, error) {
+	if len(args) != len(fn.Params) {
+		return nil, argumentsError(fn.Name, len(fn.Params), len(fn.Params), len(args))
+	}
+	if ctx.depth >= maxCallDepth {
+		return nil, fmt.Errorf("%s: %w", fn.Name, errCallDepth)
+	}
+	if err := ctx.interrupted(); err != nil {
+		return nil, err
+	}
+
+	for i, p := range fn.Params {
+		if t := fn.paramType(i); t != ValueTypeUnknown && args[i].Type() != t {
+			return nil, typeErrorf("%s: can't pass %s as %q of type %s", fn.Name, args[i].Type(), p, t)
+		}
+		vars[p] = args[i]
+	}
+
+	if fn.Generator {
+		return fn.checkResult(&Generator{Function: fn, vars: vars, ctx: ctx})
+	}
+
+	saved, gen := ctx.scope, ctx.gen
+	ctx.scope = fn.newScope(vars)
+	ctx.gen = nil
+	ctx.depth++
+	defer func() {
+		ctx.scope, ctx.gen = saved, gen
+		ctx.depth--
+	}()
+
+	v, err := fn.Body.Eval(ctx)
+	switch {
+	case err == errBreak:
+		// not in a loop of this function
+		return nil, fmt.Errorf("%s: %w", fn.Name, err)
+	case err != nil:
+		return nil, err
+	default:
+		return fn.checkResult(v)
+	}
+}
+
+// paramType returns the annotated type of a parameter,
+// or ValueTypeUnknown
+func (fn *Function) paramType(i int) ValueType {
+	if i < len(fn.Types) {
+		return fn.Types[i]
+	}
+	return ValueTypeUnknown
+}
+
+// newScope creates the scope of a call, where annotated
+// parameters only accept values of their type
+func (fn *Function) newScope(vars map[string]Value) *scope {
+	sc := &scope{vars: vars, parent: fn.scope}
+	for i, p := range fn.Params {
+		if t := fn.paramType(i); t != ValueTypeUnknown {
+			sc.setType(p, t)
+		}
+	}
+	return sc
+}
+
+// checkResult fails if the value returned doesn't have
+// the annotated result type
+func (fn *Function) checkResult(v Value) (Value, error) {
+	if fn.Result != ValueTypeUnknown && v.Type() != fn.Result {
+		return nil, typeErrorf("%s: returned %s, not %s", fn.Name, v.Type(), fn.Result)
+	}
+	return v, nil
+}
+
+// signature formats name(param: type, ...) -> type, leaving
+// out the types that aren't annotated
+func signature(name string, params []string, types []ValueType, result ValueType) string {
+	s := make([]string, len(params))
+	for i, p := range params {
+		s[i] = p
+		if i < len(types) && types[i] != ValueTypeUnknown {
+			s[i] += ": " + types[i].String()
+		}
+	}
+
+	out := fmt.Sprintf("%s(%s)", name, strings.Join(s, ", "))
+	if result != ValueTypeUnknown {
+		out += " " + resultOperator + " " + result.String()
+	}
+	return out
+}
+
+// FunctionNode represents fn name(param, ...) body end, with
+// optional param: type and -> type annotations
+type FunctionNode struct {
+	Name      string
+	Params    []string
+	Types     []ValueType
+	Result    ValueType
+	Body      BodyNode
+	Generator bool
+	Pos       Position
+}
+
+func (n *FunctionNode) GoString() string {
+	return fmt.Sprintf("&FunctionNode{%q, %q, %#v}", n.Name, n.Params, n.Body)
+}
+
+func (n *FunctionNode) String() string {
+	return fmt.Sprintf("fn %s ... end", signature(n.Name, n.Params, n.Types, n.Result))
+}
+
+// Eval defines the function in the current scope
+func (n *FunctionNode) Eval(ctx *Javalanche) (Value, error) {
+	if err := ctx.SetValue(n.Name, n.function(ctx)); err != nil {
+		return nil, err
+	}
+	return NewNull(), nil
+}
+
+// function creates the Function, enclosed by the current scope
+func (n *FunctionNode) function(ctx *Javalanche) *Function {
+	return &Function{
+		Name:      n.Name,
+		Params:    n.Params,
+		Types:     n.Types,
+		Result:    n.Result,
+		Body:      n.Body,
+		Generator: n.Generator,
+		scope:     ctx.scope,
+	}
+}
+
+// ResultAnnotation represents name(param, ...) -> type, the
+// signature of a function declaring the type it returns.
+// It's only valid after fn
+type ResultAnnotation struct {
+	Signature Node
+	Type      ValueType
+}
+
+func (n *ResultAnnotation) GoString() string {
+	return fmt.Sprintf("&ResultAnnotation{%#v, %q}", n.Signature, n.Type)
+}
+
+func (n *ResultAnnotation) String() string {
+	return fmt.Sprintf("%s %s %s", n.Signature, resultOperator, n.Type)
+}
+
+// Eval fails, as the signature isn't part of a fn
+func (n *ResultAnnotation) Eval(*Javalanche) (Value, error) {
+	return nil, fmt.Errorf("missing fn before %s", n)
+}
@@ -0,0 +1,254 @@
+package javalanche
+
+import (
+	"fmt"
+)
+
+var (
+	_ Value          = (*Generator)(nil)
+	_ IterValuer     = (*Generator)(nil)
+	_ fmt.GoStringer = (*Generator)(nil)
+	_ fmt.Stringer   = (*Generator)(nil)
+	_ Iterator       = (*generatorRun)(nil)
+
+	_ Node           = (*YieldNode)(nil)
+	_ fmt.GoStringer = (*YieldNode)(nil)
+	_ fmt.Stringer   = (*YieldNode)(nil)
+)
+
+// Generator is returned by calling a function that yields.
+// Nothing runs until it's iterated, and each loop over it
+// runs the function from the start, stopping at every yield
+// until the loop wants the next value
+type Generator struct {
+	Function *Function
+
+	vars map[string]Value
+	ctx  *Javalanche
+}
+
+func (g *Generator) GoString() string {
+	return fmt.Sprintf("&Generator{%#v}", g.Function)
+}
+
+func (g *Generator) String() string {
+	return "generator " + g.Function.String()
+}
+
+func (g *Generator) Type() ValueType {
+	return ValueTypeGenerator
+}
+
+func (g *Generator) AsFloat64() float64 {
+	return 0
+}
+
+func (g *Generator) AsString() string {
+	return g.String()
+}
+
+func (g *Generator) AsBool() bool {
+	return true
+}
+
+func (g *Generator) Equal(v Value) bool {
+	return g == v
+}
+
+// IterValue starts a new run of the function, with the
+// arguments of the call
+func (g *Generator) IterValue() Iterator {
+	vars := make(map[string]Value, len(g.vars))
+	for k, v := range g.vars {
+		vars[k] = v
+	}
+
+	return &generatorRun{
+		fn:     g.Function,
+		ctx:    g.ctx,
+		scope:  g.Function.newScope(vars),
+		depth:  g.ctx.depth + 1,
+		resume: make(chan bool),
+		out:    make(chan generatorStep),
+	}
+}
+
+// generatorRun runs the body of a generator function in its
+// own goroutine, taking turns with the loop reading its
+// values so only one of them uses the context at a time
+type generatorRun struct {
+	fn  *Function
+	ctx *Javalanche
+
+	// scope and depth of the body while the loop runs
+	scope *scope
+	depth int
+
+	resume  chan bool
+	out     chan generatorStep
+	started bool
+	done    bool
+
+	// closed is only used by the body, so yields in
+	// finally blocks don't wait again
+	closed bool
+}
+
+// generatorStep is what the body gives back when it yields
+// a value, or finishes
+type generatorStep struct {
+	value Value
+	err   error
+	done  bool
+}
+
+// Next runs the body until its next yield
+func (r *generatorRun) Next() (Value, bool, error) {
+	switch {
+	case r.done:
+		return nil, false, nil
+	case !r.started && r.depth > maxCallDepth:
+		r.done = true
+		return nil, false, fmt.Errorf("%s: %w", r.fn.Name, errCallDepth)
+	}
+
+	step := r.step(true)
+	switch {
+	case step.err != nil:
+		return nil, false, step.err
+	case step.done:
+		return nil, false, nil
+	default:
+		return step.value, true, nil
+	}
+}
+
+// Close stops a body waiting at a yield, making the yield
+// fail so it finishes without running further
+func (r *generatorRun) Close() {
+	if r.started && !r.done {
+		r.step(false)
+	}
+	r.done = true
+}
+
+// step lets the body run until it yields or finishes, with the
+// context switched to the scope of the body
+func (r *generatorRun) step(resume bool) generatorStep {
+	ctx := r.ctx
+	scope, depth, gen := ctx.scope, ctx.depth, ctx.gen
+	ctx.scope, ctx.depth, ctx.gen = r.scope, r.depth, r
+
+	if r.started {
+		r.resume <- resume
+	} else {
+		r.started = true
+		go r.run()
+	}
+
+	step := <-r.out
+	r.scope, r.depth = ctx.scope, ctx.depth
+	ctx.scope, ctx.depth, ctx.gen = scope, depth, gen
+
+	if step.done {
+		r.done = true
+	}
+	return step
+}
+
+// run evaluates the body, and reports when it's finished.
+// Panics are reported as errors, as nothing would recover
+// them in this goroutine
+func (r *generatorRun) run() {
+	var err error
+	defer func() {
+		if p := recover(); p != nil {
+			err = fmt.Errorf("%s: %w: %v", r.fn.Name, errPanic, p)
+		}
+		r.out <- generatorStep{err: err, done: true}
+	}()
+
+	_, err = r.fn.Body.Eval(r.ctx)
+	if err == errBreak {
+		// not in a loop of this function
+		err = fmt.Errorf("%s: %w", r.fn.Name, err)
+	}
+}
+
+// yield hands a value to the loop and waits for it to want
+// the next one
+func (r *generatorRun) yield(v Value) error {
+	if r.closed {
+		return errClosed
+	}
+
+	r.out <- generatorStep{value: v}
+	if !<-r.resume {
+		r.closed = true
+		return errClosed
+	}
+	return nil
+}
+
+// YieldNode represents yield value, giving the next value
+// of a generator
+type YieldNode struct {
+	Value Node
+}
+
+func (n *YieldNode) GoString() string {
+	return fmt.Sprintf("&YieldNode{%#v}", n.Value)
+}
+
+func (n *YieldNode) String() string {
+	return fmt.Sprintf("yield %s", n.Value)
+}
+
+// Eval waits until the loop reading the generator wants
+// another value
+func (n *YieldNode) Eval(ctx *Javalanche) (Value, error) {
+	if ctx.gen == nil {
+		return nil, errYield
+	}
+
+	v, err := n.Value.Eval(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ctx.gen.yield(v); err != nil {
+		return nil, err
+	}
+	return NewNull(), nil
+}
+
+// containsYield tells if a function body yields, outside
+// of the functions declared in it
+func containsYield(node Node) bool {
+	switch n := node.(type) {
+	case *YieldNode:
+		return true
+	case BodyNode:
+		for _, node := range n {
+			if containsYield(node) {
+				return true
+			}
+		}
+	case *IfElseNode:
+		return containsYield(n.TrueBody) || containsYield(n.FalseBody)
+	case *ForNode:
+		return containsYield(n.Body)
+	case *ForInNode:
+		return containsYield(n.Body)
+	case *MatchNode:
+		for _, c := range n.Cases {
+			if containsYield(c.Body) {
+				return true
+			}
+		}
+		return containsYield(n.Else)
+	case *TryNode:
+		return containsYield(n.Body) || containsYield(n.Catch) || containsYield(n.Finally)
+	}
+	return false
+}
@@ -3,7 +3,9 @@ package javalanche
 import (
 	"fmt"
 	"math"
+	"math/big"
 	"strconv"
+	"strings"
 )
 
 var (
@@ -21,6 +23,14 @@ var (
 	_ LesserEqualValuer = (*IntegerLiteral)(nil)
 	_ LesserValuer      = (*IntegerLiteral)(nil)
 	_ GreaterValuer     = (*IntegerLiteral)(nil)
+	_ ModValuer         = (*IntegerLiteral)(nil)
+	_ BitAndValuer      = (*IntegerLiteral)(nil)
+	_ BitOrValuer       = (*IntegerLiteral)(nil)
+	_ XorValuer         = (*IntegerLiteral)(nil)
+	_ BitNotValuer      = (*IntegerLiteral)(nil)
+	_ ShiftLeftValuer   = (*IntegerLiteral)(nil)
+	_ ShiftRightValuer  = (*IntegerLiteral)(nil)
+	_ RangeValuer       = (*IntegerLiteral)(nil)
 )
 
 type IntegerLiteral struct {
@@ -31,15 +41,57 @@ func NewInteger(n int) *IntegerLiteral {
 	return &IntegerLiteral{Value: n}
 }
 
+// NewIntegerString converts string value into integer value.
+// It accepts 0x, 0o and 0b prefixes, and `_` between digits
 func NewIntegerString(s string) (*IntegerLiteral, error) {
-	intVal, err := strconv.Atoi(s)
-	if err == nil {
-		return &IntegerLiteral{Value: intVal}, nil
+	if digits, base, ok := integerDigits(s); ok {
+		intVal, err := strconv.ParseInt(digits, base, strconv.IntSize)
+		if err == nil {
+			return &IntegerLiteral{Value: int(intVal)}, nil
+		}
 	}
 
 	return nil, &ErrInvalidValue{s}
 }
 
+// integerDigits prepares an integer literal for strconv and
+// math/big, returning the base to use. Prefixed literals are
+// parsed with base 0, which also validates their separators,
+// others have their separators removed so leading zeros
+// aren't taken as octal
+func integerDigits(s string) (string, int, bool) {
+	unsigned := strings.TrimLeft(s, "+-")
+	if len(unsigned) > 1 && unsigned[0] == '0' && strings.ContainsRune("xXoObB", rune(unsigned[1])) {
+		return s, 0, true
+	}
+
+	digits, ok := stripDigitSeparators(s)
+	return digits, 10, ok
+}
+
+// stripDigitSeparators removes the `_` between digits,
+// failing if a separator isn't surrounded by digits
+func stripDigitSeparators(s string) (string, bool) {
+	if !strings.ContainsRune(s, '_') {
+		return s, true
+	}
+
+	isDigitAt := func(i int) bool {
+		return i >= 0 && i < len(s) && s[i] >= '0' && s[i] <= '9'
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		switch {
+		case s[i] != '_':
+			b.WriteByte(s[i])
+		case !isDigitAt(i-1) || !isDigitAt(i+1):
+			return "", false
+		}
+	}
+	return b.String(), true
+}
+
 func (n *IntegerLiteral) GoString() string {
 	return fmt.Sprintf("NewInteger(%v)", n.Value)
 }
@@ -75,19 +127,38 @@ func (n *IntegerLiteral) Equal(v Value) bool {
 	if m, ok := v.(*FloatLiteral); ok {
 		return float64(n.Value) == m.Value
 	}
+	if m, ok := v.(*BigIntegerLiteral); ok {
+		return m.Equal(n)
+	}
+	if m, ok := v.(*DecimalLiteral); ok {
+		return m.Equal(n)
+	}
 
 	return false
 }
+
+// big returns the integer promoted to a BigIntegerLiteral
+func (n *IntegerLiteral) big() *BigIntegerLiteral {
+	return &BigIntegerLiteral{Value: big.NewInt(int64(n.Value))}
+}
+
 func (n *IntegerLiteral) AddValue(v Value) (Value, error) {
 	// if v is of the specified type
 	// the switch will test the supported types and use the best match
 	switch right := v.(type) {
 	case *IntegerLiteral:
-		res := n.Value + right.Value
-		return NewInteger(res), nil
+		if res, ok := addInt(n.Value, right.Value); ok {
+			return NewInteger(res), nil
+		}
+		return n.big().AddValue(right)
 	case *FloatLiteral:
 		res := (float64)(n.Value) + right.Value
 		return NewFloat(res), nil
+	case *BigIntegerLiteral:
+		return n.big().AddValue(right)
+	case *DecimalLiteral:
+		left, _ := right.promote(n)
+		return left.AddValue(right)
 	default:
 		return nil, errInvalidTypes
 	}
@@ -96,11 +167,18 @@ func (n *IntegerLiteral) AddValue(v Value) (Value, error) {
 func (n *IntegerLiteral) SubValue(v Value) (Value, error) {
 	switch right := v.(type) {
 	case *IntegerLiteral:
-		res := n.Value - right.Value
-		return NewInteger(res), nil
+		if res, ok := subInt(n.Value, right.Value); ok {
+			return NewInteger(res), nil
+		}
+		return n.big().SubValue(right)
 	case *FloatLiteral:
 		res := (float64)(n.Value) - (right.Value)
 		return NewFloat(res), nil
+	case *BigIntegerLiteral:
+		return n.big().SubValue(right)
+	case *DecimalLiteral:
+		left, _ := right.promote(n)
+		return left.SubValue(right)
 	default:
 		return nil, errInvalidTypes
 	}
@@ -120,6 +198,11 @@ func (n *IntegerLiteral) DivValue(v Value) (Value, error) {
 		}
 		res := (float64)(n.Value) / (right.Value)
 		return NewFloat(res), nil
+	case *BigIntegerLiteral:
+		return n.big().DivValue(right)
+	case *DecimalLiteral:
+		left, _ := right.promote(n)
+		return left.DivValue(right)
 	default:
 		return nil, errInvalidTypes
 	}
@@ -128,17 +211,30 @@ func (n *IntegerLiteral) DivValue(v Value) (Value, error) {
 func (n *IntegerLiteral) MulValue(v Value) (Value, error) {
 	switch right := v.(type) {
 	case *IntegerLiteral:
-		res := n.Value * right.Value
-		return NewInteger(res), nil
+		if res, ok := mulInt(n.Value, right.Value); ok {
+			return NewInteger(res), nil
+		}
+		return n.big().MulValue(right)
 	case *FloatLiteral:
 		res := (float64)(n.Value) * (right.Value)
 		return NewFloat(res), nil
+	case *BigIntegerLiteral:
+		return n.big().MulValue(right)
+	case *DecimalLiteral:
+		left, _ := right.promote(n)
+		return left.MulValue(right)
+	case *StringLiteral:
+		// 3 * "ab" -> "ababab"
+		return right.MulValue(n)
 	default:
 		return nil, errInvalidTypes
 	}
 }
 
 func (n *IntegerLiteral) NegValue() (Value, error) {
+	if n.Value == math.MinInt {
+		return n.big().NegValue()
+	}
 	return NewInteger(-n.Value), nil
 }
 
@@ -148,6 +244,8 @@ func (n *IntegerLiteral) UpValue(v Value) (Value, error) {
 		return n.powInt(n.Value, right.Value)
 	case *FloatLiteral:
 		return n.powFloat(float64(n.Value), right.Value)
+	case *BigIntegerLiteral:
+		return n.big().UpValue(right)
 	default:
 		return nil, errInvalidTypes
 	}
@@ -157,32 +255,87 @@ func (*IntegerLiteral) powFloat(a, b float64) (Value, error) {
 	return NewFloat(math.Pow(a, b)), nil
 }
 
-func (*IntegerLiteral) powInt(a, b int) (Value, error) {
-	var res int
-
+func (n *IntegerLiteral) powInt(a, b int) (Value, error) {
 	switch {
 	case b < 0:
 		// negative
-		res = powIntPositive(a, -b)
-		return NewFloat(1 / float64(res)), nil
+		return NewFloat(math.Pow(float64(a), float64(b))), nil
 	case b == 0:
-		res = 1
+		return NewInteger(1), nil
 	default:
 		// positive
-		res = powIntPositive(a, b)
+		if res, ok := powIntPositive(a, b); ok {
+			return NewInteger(res), nil
+		}
+		return powBig(big.NewInt(int64(a)), b)
 	}
-
-	return NewInteger(res), nil
 }
 
-func powIntPositive(base, exponent int) int {
+// powIntPositive raises base to a non-negative exponent by
+// squaring, reporting false if the result doesn't fit in an int
+func powIntPositive(base, exponent int) (int, bool) {
+	switch {
+	case exponent == 0:
+		return 1, true
+	case base == 0, base == 1:
+		return base, true
+	case base == -1 && exponent%2 == 0:
+		return 1, true
+	case base == -1:
+		return -1, true
+	}
+
+	var ok bool
 	result := 1
 
-	for i := 0; i < exponent; i++ {
-		result *= base
+	for {
+		if exponent%2 == 1 {
+			if result, ok = mulInt(result, base); !ok {
+				return 0, false
+			}
+		}
+
+		exponent /= 2
+		if exponent == 0 {
+			return result, true
+		}
+
+		// what's left needs at least base squared
+		if base, ok = mulInt(base, base); !ok {
+			return 0, false
+		}
+	}
+}
+
+// addInt adds two ints, reporting false on overflow
+func addInt(a, b int) (int, bool) {
+	res := a + b
+	if (b > 0 && res < a) || (b < 0 && res > a) {
+		return 0, false
+	}
+	return res, true
+}
+
+// subInt subtracts two ints, reporting false on overflow
+func subInt(a, b int) (int, bool) {
+	res := a - b
+	if (b > 0 && res > a) || (b < 0 && res < a) {
+		return 0, false
+	}
+	return res, true
+}
+
+// mulInt multiplies two ints, reporting false on overflow
+func mulInt(a, b int) (int, bool) {
+	if a == 0 || b == 0 {
+		return 0, true
 	}
 
-	return result
+	res := a * b
+	if res/b != a || (a == -1 && b == math.MinInt) || (b == -1 && a == math.MinInt) {
+		return 0, false
+	}
+	return res, true
 }
 
 func (n *IntegerLiteral) LesserValue(v Value) (Value, error) {
@@ -193,6 +346,11 @@ func (n *IntegerLiteral) LesserValue(v Value) (Value, error) {
 	case *FloatLiteral:
 		res := ((float64)(n.Value) < (right.Value))
 		return NewBoolean(res), nil
+	case *BigIntegerLiteral:
+		return n.big().LesserValue(right)
+	case *DecimalLiteral:
+		left, _ := right.promote(n)
+		return left.LesserValue(right)
 	default:
 		return nil, errInvalidTypes
 	}
@@ -206,6 +364,11 @@ func (n *IntegerLiteral) GreaterValue(v Value) (Value, error) {
 	case *FloatLiteral:
 		res := ((float64)(n.Value) > (right.Value))
 		return NewBoolean(res), nil
+	case *BigIntegerLiteral:
+		return n.big().GreaterValue(right)
+	case *DecimalLiteral:
+		left, _ := right.promote(n)
+		return left.GreaterValue(right)
 	default:
 		return nil, errInvalidTypes
 	}
@@ -219,6 +382,11 @@ func (n *IntegerLiteral) GreaterEqualValue(v Value) (Value, error) {
 	case *FloatLiteral:
 		res := ((float64)(n.Value) >= (right.Value))
 		return NewBoolean(res), nil
+	case *BigIntegerLiteral:
+		return n.big().GreaterEqualValue(right)
+	case *DecimalLiteral:
+		left, _ := right.promote(n)
+		return left.GreaterEqualValue(right)
 	default:
 		return nil, errInvalidTypes
 	}
@@ -232,6 +400,11 @@ func (n *IntegerLiteral) LesserEqualValue(v Value) (Value, error) {
 	case *FloatLiteral:
 		res := ((float64)(n.Value) <= (right.Value))
 		return NewBoolean(res), nil
+	case *BigIntegerLiteral:
+		return n.big().LesserEqualValue(right)
+	case *DecimalLiteral:
+		left, _ := right.promote(n)
+		return left.LesserEqualValue(right)
 	default:
 		return nil, errInvalidTypes
 	}
@@ -246,7 +419,105 @@ func (n *IntegerLiteral) ModValue(v Value) (Value, error) {
 		}
 		res := n.Value % right.Value
 		return NewInteger(res), nil
+	case *BigIntegerLiteral:
+		return n.big().ModValue(right)
 	default:
 		return nil, errInvalidTypes
 	}
 }
+
+// BitAndValue is bitwise and operation
+func (n *IntegerLiteral) BitAndValue(v Value) (Value, error) {
+	switch right := v.(type) {
+	case *IntegerLiteral:
+		return NewInteger(n.Value & right.Value), nil
+	case *BigIntegerLiteral:
+		return n.big().BitAndValue(right)
+	default:
+		return nil, errNotInteger
+	}
+}
+
+// BitOrValue is bitwise or operation
+func (n *IntegerLiteral) BitOrValue(v Value) (Value, error) {
+	switch right := v.(type) {
+	case *IntegerLiteral:
+		return NewInteger(n.Value | right.Value), nil
+	case *BigIntegerLiteral:
+		return n.big().BitOrValue(right)
+	default:
+		return nil, errNotInteger
+	}
+}
+
+// XorValue is bitwise exclusive or operation
+func (n *IntegerLiteral) XorValue(v Value) (Value, error) {
+	switch right := v.(type) {
+	case *IntegerLiteral:
+		return NewInteger(n.Value ^ right.Value), nil
+	case *BigIntegerLiteral:
+		return n.big().XorValue(right)
+	default:
+		return nil, errNotInteger
+	}
+}
+
+// BitNotValue is bitwise complement operation
+func (n *IntegerLiteral) BitNotValue() (Value, error) {
+	return NewInteger(^n.Value), nil
+}
+
+// ShiftLeftValue shifts bits to the left, promoting to
+// big integer if they don't fit
+func (n *IntegerLiteral) ShiftLeftValue(v Value) (Value, error) {
+	count, err := shiftCount(v)
+	if err != nil {
+		return nil, err
+	}
+
+	if count < strconv.IntSize {
+		if res := n.Value << count; res>>count == n.Value {
+			return NewInteger(res), nil
+		}
+	}
+	return n.big().ShiftLeftValue(v)
+}
+
+// ShiftRightValue shifts bits to the right, keeping the sign
+func (n *IntegerLiteral) ShiftRightValue(v Value) (Value, error) {
+	count, err := shiftCount(v)
+	if err != nil {
+		return nil, err
+	}
+	return NewInteger(n.Value >> count), nil
+}
+
+// RangeValue returns the range from n to the given integer
+func (n *IntegerLiteral) RangeValue(v Value, inclusive bool) (Value, error) {
+	switch end := v.(type) {
+	case *IntegerLiteral:
+		return NewRange(n.Value, end.Value, inclusive), nil
+	case *BigIntegerLiteral:
+		return nil, fmt.Errorf("range to %v: %w", end, errTooLarge)
+	default:
+		return nil, errNotInteger
+	}
+}
+
+// shiftCount validates the right side of a shift operation
+func shiftCount(v Value) (uint, error) {
+	switch right := v.(type) {
+	case *IntegerLiteral:
+		if right.Value < 0 {
+			return 0, errNegShift
+		}
+		return uint(right.Value), nil
+	case *BigIntegerLiteral:
+		if right.Value.Sign() < 0 {
+			return 0, errNegShift
+		}
+		return 0, errOverflow
+	default:
+		return 0, errNotInteger
+	}
+}
@@ -0,0 +1,45 @@
+package javalanche
+
+import (
+	"fmt"
+	"strings"
+)
+
+var (
+	_ Node           = (*LambdaExpression)(nil)
+	_ fmt.GoStringer = (*LambdaExpression)(nil)
+	_ fmt.Stringer   = (*LambdaExpression)(nil)
+)
+
+// lambdaName names anonymous functions in errors
+const lambdaName = "lambda"
+
+// LambdaExpression represents (param, ...) => body
+type LambdaExpression struct {
+	Params []string
+	Body   Node
+}
+
+func (n *LambdaExpression) GoString() string {
+	return fmt.Sprintf("&LambdaExpression{%q, %#v}", n.Params, n.Body)
+}
+
+func (n *LambdaExpression) String() string {
+	return fmt.Sprintf("(%s) => %s", strings.Join(n.Params, ", "), n.Body)
+}
+
+// Eval creates a function enclosed by the current scope,
+// so it can use and change its variables after the
+// scope is left
+func (n *LambdaExpression) Eval(ctx *Javalanche) (Value, error) {
+	if n.Body == nil {
+		return nil, fmt.Errorf("missing %q after (%s)", lambdaOperator, strings.Join(n.Params, ", "))
+	}
+
+	return &Function{
+		Name:   lambdaName,
+		Params: n.Params,
+		Body:   BodyNode{n.Body},
+		scope:  ctx.scope,
+	}, nil
+}
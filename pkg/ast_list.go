@@ -0,0 +1,184 @@
+package javalanche
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var (
+	_ Value          = (*ListLiteral)(nil)
+	_ Node           = (*ListLiteral)(nil)
+	_ fmt.GoStringer = (*ListLiteral)(nil)
+	_ fmt.Stringer   = (*ListLiteral)(nil)
+	_ AddValuer      = (*ListLiteral)(nil)
+	_ ContainsValuer = (*ListLiteral)(nil)
+	_ IndexValuer    = (*ListLiteral)(nil)
+	_ SliceValuer    = (*ListLiteral)(nil)
+	_ IterValuer     = (*ListLiteral)(nil)
+
+	_ Node           = (*ListExpression)(nil)
+	_ fmt.GoStringer = (*ListExpression)(nil)
+	_ fmt.Stringer   = (*ListExpression)(nil)
+)
+
+// ListLiteral is an ordered list of Values
+type ListLiteral struct {
+	Items []Value
+}
+
+// NewList returns a list of the given values
+func NewList(items ...Value) *ListLiteral {
+	return &ListLiteral{Items: items}
+}
+
+func (n *ListLiteral) GoString() string {
+	s := make([]string, len(n.Items))
+	for i, v := range n.Items {
+		s[i] = fmt.Sprintf("%#v", v)
+	}
+	return fmt.Sprintf("NewList(%s)", strings.Join(s, ", "))
+}
+
+// String returns the list with its strings quoted, like
+// [1, "a", true]
+func (n *ListLiteral) String() string {
+	s := make([]string, len(n.Items))
+	for i, v := range n.Items {
+		s[i] = literalString(v)
+	}
+	return "[" + strings.Join(s, ", ") + "]"
+}
+
+// literalString formats a value contained in another,
+// quoting strings
+func literalString(v Value) string {
+	if str, ok := v.(*StringLiteral); ok {
+		return strconv.Quote(str.Value)
+	}
+	return fmt.Sprint(v)
+}
+
+func (n *ListLiteral) Type() ValueType {
+	return ValueTypeList
+}
+
+func (n *ListLiteral) AsFloat64() float64 {
+	return 0
+}
+
+func (n *ListLiteral) AsString() string {
+	return n.String()
+}
+
+func (n *ListLiteral) AsBool() bool {
+	return len(n.Items) > 0
+}
+
+func (n *ListLiteral) Eval(ctx *Javalanche) (Value, error) {
+	return n, nil
+}
+
+// Equal compares lists item by item
+func (n *ListLiteral) Equal(v Value) bool {
+	m, ok := v.(*ListLiteral)
+	if !ok || len(m.Items) != len(n.Items) {
+		return false
+	}
+
+	for i, item := range n.Items {
+		if !item.Equal(m.Items[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// AddValue concatenates two lists
+func (n *ListLiteral) AddValue(v Value) (Value, error) {
+	if m, ok := v.(*ListLiteral); ok {
+		items := make([]Value, 0, len(n.Items)+len(m.Items))
+		items = append(items, n.Items...)
+		items = append(items, m.Items...)
+		return NewList(items...), nil
+	}
+	return nil, errInvalidTypes
+}
+
+// ContainsValue tells if any item is == to the given value
+func (n *ListLiteral) ContainsValue(v Value) (Value, error) {
+	for _, item := range n.Items {
+		if looseEqual(item, v) {
+			return NewBoolean(true), nil
+		}
+	}
+	return NewBoolean(false), nil
+}
+
+// IndexValue returns the item at the given position
+func (n *ListLiteral) IndexValue(v Value) (Value, error) {
+	i, err := resolveIndex(v, len(n.Items))
+	if err != nil {
+		return nil, err
+	}
+	return n.Items[i], nil
+}
+
+// SliceValue returns a new list with the items between
+// the given positions
+func (n *ListLiteral) SliceValue(start, end Value) (Value, error) {
+	from, until, err := resolveSlice(start, end, len(n.Items))
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]Value, until-from)
+	copy(items, n.Items[from:until])
+	return NewList(items...), nil
+}
+
+// IterValue gives the items in order
+func (n *ListLiteral) IterValue() Iterator {
+	i := 0
+	return IteratorFunc(func() (Value, bool, error) {
+		if i >= len(n.Items) {
+			return nil, false, nil
+		}
+		i++
+		return n.Items[i-1], true, nil
+	})
+}
+
+// ListExpression represents a [a, b, ...] list literal
+type ListExpression struct {
+	Items []Node
+}
+
+func (n *ListExpression) GoString() string {
+	s := make([]string, len(n.Items))
+	for i, item := range n.Items {
+		s[i] = fmt.Sprintf("%#v", item)
+	}
+	return fmt.Sprintf("&ListExpression{%s}", strings.Join(s, ", "))
+}
+
+func (n *ListExpression) String() string {
+	s := make([]string, len(n.Items))
+	for i, item := range n.Items {
+		s[i] = fmt.Sprint(item)
+	}
+	return "[" + strings.Join(s, ", ") + "]"
+}
+
+// Eval evaluates the items in order into a new list
+func (n *ListExpression) Eval(ctx *Javalanche) (Value, error) {
+	items := make([]Value, 0, len(n.Items))
+	for _, node := range n.Items {
+		v, err := node.Eval(ctx)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, v)
+	}
+	return NewList(items...), nil
+}
@@ -0,0 +1,79 @@
+package javalanche
+
+var (
+	_ Node = (*MatchNode)(nil)
+)
+
+// MatchNode represents match subject case ... else ... end
+type MatchNode struct {
+	Subject Node
+	Cases   []*MatchCase
+	Else    Node
+	Pos     Position
+}
+
+// MatchCase is a case of a match, chosen if any of its
+// patterns matches and then its guard, if any, is true
+type MatchCase struct {
+	Patterns []Pattern
+	Guard    Node
+	Body     BodyNode
+}
+
+// Eval evaluates the subject and the body of the first
+// case it matches, or else
+func (n *MatchNode) Eval(ctx *Javalanche) (Value, error) {
+	val, err := n.eval(ctx)
+	return val, traced(err, "match", n.Pos)
+}
+
+func (n *MatchNode) eval(ctx *Javalanche) (Value, error) {
+	val, err := n.Subject.Eval(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, c := range n.Cases {
+		ok, err := c.match(ctx, val)
+		switch {
+		case err != nil:
+			return nil, err
+		case ok:
+			return c.Body.Eval(ctx)
+		}
+	}
+
+	if n.Else != nil {
+		return n.Else.Eval(ctx)
+	}
+	return NewNull(), nil
+}
+
+// match tries the patterns in order, assigning the variables
+// of the first that matches before checking the guard
+func (c *MatchCase) match(ctx *Javalanche, val Value) (bool, error) {
+	for _, p := range c.Patterns {
+		bindings := make(map[string]Value)
+		if !p.Match(val, bindings) {
+			continue
+		}
+
+		for name, v := range bindings {
+			if err := ctx.SetValue(name, v); err != nil {
+				return false, err
+			}
+		}
+
+		if c.Guard == nil {
+			return true, nil
+		}
+
+		cond, err := c.Guard.Eval(ctx)
+		if err != nil {
+			return false, err
+		}
+		return cond.AsBool(), nil
+	}
+
+	return false, nil
+}
@@ -0,0 +1,70 @@
+package javalanche
+
+import "fmt"
+
+var (
+	_ Value          = (*NullLiteral)(nil)
+	_ Node           = (*NullLiteral)(nil)
+	_ fmt.GoStringer = (*NullLiteral)(nil)
+	_ fmt.Stringer   = (*NullLiteral)(nil)
+)
+
+// NullLiteral is the absent value, written `null` or `nil`.
+// Statements like print, assignments and loops evaluate to it
+type NullLiteral struct{}
+
+// NewNull returns the null value
+func NewNull() *NullLiteral {
+	return &NullLiteral{}
+}
+
+func (n *NullLiteral) GoString() string {
+	return "NewNull()"
+}
+
+func (n *NullLiteral) String() string {
+	return "null"
+}
+
+func (n *NullLiteral) Type() ValueType {
+	return ValueTypeNull
+}
+
+func (n *NullLiteral) AsFloat64() float64 {
+	return 0
+}
+
+func (n *NullLiteral) AsString() string {
+	return n.String()
+}
+
+// AsBool makes null falsy
+func (n *NullLiteral) AsBool() bool {
+	return false
+}
+
+func (n *NullLiteral) Eval(ctx *Javalanche) (Value, error) {
+	return n, nil
+}
+
+// Equal tells if the other value is null too
+func (n *NullLiteral) Equal(v Value) bool {
+	return isNull(v)
+}
+
+// isNull tells if a Value is null, or missing
+func isNull(v Value) bool {
+	if v == nil {
+		return true
+	}
+	_, ok := v.(*NullLiteral)
+	return ok
+}
+
+// orNull replaces a missing Value with null
+func orNull(v Value) Value {
+	if v == nil {
+		return NewNull()
+	}
+	return v
+}
@@ -0,0 +1,96 @@
+package javalanche
+
+// binaryMethods names the methods classes define to overload
+// binary operators, and the reflected ones called on the right
+// operand when the left one doesn't overload the operator
+var binaryMethods = map[string][2]string{
+	"+":   {"__add__", "__radd__"},
+	"-":   {"__sub__", "__rsub__"},
+	"*":   {"__mul__", "__rmul__"},
+	"/":   {"__div__", "__rdiv__"},
+	"%":   {"__mod__", "__rmod__"},
+	"^":   {"__pow__", "__rpow__"},
+	"&":   {"__and__", "__rand__"},
+	"|":   {"__or__", "__ror__"},
+	"xor": {"__xor__", "__rxor__"},
+	"<<":  {"__lshift__", "__rlshift__"},
+	">>":  {"__rshift__", "__rrshift__"},
+	"==":  {"__eq__", "__eq__"},
+	"<":   {"__lt__", "__gt__"},
+	">":   {"__gt__", "__lt__"},
+	"<=":  {"__le__", "__ge__"},
+	">=":  {"__ge__", "__le__"},
+}
+
+// unaryMethods names the methods classes define to overload
+// prefix operators
+var unaryMethods = map[string]string{
+	"-": "__neg__",
+	"+": "__pos__",
+	"~": "__invert__",
+	"!": "__not__",
+}
+
+// containsMethod overloads `in`, called on the right operand
+const containsMethod = "__contains__"
+
+// overloadBinary calls the method an object defines for the
+// operator. ok is false when neither operand overloads it.
+// `!=` negates `__eq__`
+func overloadBinary(ctx *Javalanche, op string, left, right Value) (v Value, ok bool, err error) {
+	negate := op == "!="
+	if negate {
+		op = "=="
+	}
+
+	var m *Method
+	var arg Value
+
+	switch names, found := binaryMethods[op]; {
+	case op == "in":
+		m, arg = overloadMethod(right, containsMethod), left
+	case !found:
+		return nil, false, nil
+	default:
+		m, arg = overloadMethod(left, names[0]), right
+		if m == nil {
+			m, arg = overloadMethod(right, names[1]), left
+		}
+	}
+
+	if m == nil {
+		return nil, false, nil
+	}
+
+	v, err = m.CallValue(ctx, arg)
+	if err == nil && negate {
+		v = NewBoolean(!v.AsBool())
+	}
+	return v, true, err
+}
+
+// overloadUnary calls the method an object defines for a
+// prefix operator. ok is false when it doesn't overload it
+func overloadUnary(ctx *Javalanche, op string, val Value) (Value, bool, error) {
+	m := overloadMethod(val, unaryMethods[op])
+	if m == nil {
+		return nil, false, nil
+	}
+
+	v, err := m.CallValue(ctx)
+	return v, true, err
+}
+
+// overloadMethod returns the named method of an object,
+// or nil
+func overloadMethod(v Value, name string) *Method {
+	o, ok := v.(*Object)
+	if !ok || name == "" {
+		return nil
+	}
+
+	if fn, c := o.Class.method(name); fn != nil {
+		return &Method{This: o, Function: fn, Class: c}
+	}
+	return nil
+}
@@ -0,0 +1,312 @@
+package javalanche
+
+import (
+	"fmt"
+	"strings"
+)
+
+var (
+	_ Pattern = (*WildcardPattern)(nil)
+	_ Pattern = (*BindPattern)(nil)
+	_ Pattern = (*ValuePattern)(nil)
+	_ Pattern = (*TypePattern)(nil)
+	_ Pattern = (*RangePattern)(nil)
+	_ Pattern = (*SteppedRangePattern)(nil)
+	_ Pattern = (*ListPattern)(nil)
+)
+
+// Pattern is what a match case compares the subject with
+type Pattern interface {
+	fmt.Stringer
+
+	// Match tells if the value fits the pattern, adding
+	// the variables it binds
+	Match(v Value, bindings map[string]Value) bool
+}
+
+// WildcardPattern is `_`, it matches anything
+type WildcardPattern struct{}
+
+func (*WildcardPattern) String() string {
+	return "_"
+}
+
+func (*WildcardPattern) Match(Value, map[string]Value) bool {
+	return true
+}
+
+// BindPattern matches anything and assigns it to a variable
+type BindPattern struct {
+	Name string
+}
+
+func (p *BindPattern) String() string {
+	return p.Name
+}
+
+func (p *BindPattern) Match(v Value, bindings map[string]Value) bool {
+	bindings[p.Name] = v
+	return true
+}
+
+// ValuePattern matches values strictly equal to a literal
+type ValuePattern struct {
+	Value Value
+}
+
+func (p *ValuePattern) String() string {
+	if s, ok := p.Value.(*StringLiteral); ok {
+		return fmt.Sprintf("%q", s.Value)
+	}
+	return fmt.Sprint(p.Value)
+}
+
+func (p *ValuePattern) Match(v Value, _ map[string]Value) bool {
+	return strictEqual(p.Value, v)
+}
+
+// TypePattern matches values of a type, like `case int`
+type TypePattern struct {
+	Type ValueType
+}
+
+func (p *TypePattern) String() string {
+	return p.Type.String()
+}
+
+func (p *TypePattern) Match(v Value, _ map[string]Value) bool {
+	return v.Type() == p.Type
+}
+
+// RangePattern matches values between two bounds, `1..5`
+// including the upper one and `1..<5` excluding it
+type RangePattern struct {
+	Low       Value
+	High      Value
+	Inclusive bool
+}
+
+func (p *RangePattern) String() string {
+	op := "..<"
+	if p.Inclusive {
+		op = ".."
+	}
+	return fmt.Sprintf("%s%s%s", p.Low, op, p.High)
+}
+
+func (p *RangePattern) Match(v Value, _ map[string]Value) bool {
+	if isBool(v) || isNull(v) {
+		return false
+	}
+
+	above := compareValues(p.Low, v, "<=")
+	if p.Inclusive {
+		return above && compareValues(v, p.High, "<=")
+	}
+	return above && compareValues(v, p.High, "<")
+}
+
+// SteppedRangePattern matches the integers of a range with
+// a step, `1..10 by 2`
+type SteppedRangePattern struct {
+	Range *RangeLiteral
+}
+
+func (p *SteppedRangePattern) String() string {
+	return p.Range.String()
+}
+
+func (p *SteppedRangePattern) Match(v Value, _ map[string]Value) bool {
+	found, err := p.Range.ContainsValue(v)
+	return err == nil && found.AsBool()
+}
+
+// ListPattern matches lists of the same length whose
+// items match each pattern, like `case [x, 0]`
+type ListPattern struct {
+	Items []Pattern
+}
+
+func (p *ListPattern) String() string {
+	s := make([]string, len(p.Items))
+	for i, item := range p.Items {
+		s[i] = item.String()
+	}
+	return "[" + strings.Join(s, ", ") + "]"
+}
+
+func (p *ListPattern) Match(v Value, bindings map[string]Value) bool {
+	list, ok := v.(*ListLiteral)
+	if !ok || len(list.Items) != len(p.Items) {
+		return false
+	}
+
+	for i, item := range p.Items {
+		if !item.Match(list.Items[i], bindings) {
+			return false
+		}
+	}
+	return true
+}
+
+// isBool tells if a Value is a boolean
+func isBool(v Value) bool {
+	return v.Type() == ValueTypeBool
+}
+
+// compareValues applies a comparison operator, considering
+// values that can't be compared as not satisfying it
+func compareValues(left Value, right Value, op string) bool {
+	n := &BinaryExpression{Op: op}
+	res, err := n.evalOperator(left, right)
+	return err == nil && res.AsBool()
+}
+
+// isCatchAll tells if a pattern matches anything
+func isCatchAll(p Pattern) bool {
+	switch p.(type) {
+	case *WildcardPattern, *BindPattern:
+		return true
+	default:
+		return false
+	}
+}
+
+// coversPattern tells if every value matching q also matches p,
+// so a case with q after a case with p can never be chosen
+func coversPattern(p, q Pattern) bool {
+	if isCatchAll(p) {
+		return true
+	}
+
+	switch q := q.(type) {
+	case *ValuePattern:
+		return p.Match(q.Value, make(map[string]Value))
+	case *TypePattern:
+		tp, ok := p.(*TypePattern)
+		return ok && tp.Type == q.Type
+	case *RangePattern:
+		rp, ok := p.(*RangePattern)
+		return ok && rp.Low.Equal(q.Low) && rp.High.Equal(q.High) &&
+			(rp.Inclusive || !q.Inclusive)
+	case *SteppedRangePattern:
+		sp, ok := p.(*SteppedRangePattern)
+		return ok && sp.Range.Equal(q.Range)
+	case *ListPattern:
+		lp, ok := p.(*ListPattern)
+		if !ok || len(lp.Items) != len(q.Items) {
+			return false
+		}
+		for i := range lp.Items {
+			if !coversPattern(lp.Items[i], q.Items[i]) {
+				return false
+			}
+		}
+		return true
+	default:
+		return false
+	}
+}
+
+// valueTypeByName finds the ValueType used by type patterns
+func valueTypeByName(name string) (ValueType, bool) {
+	for t := ValueTypeInt; t <= ValueTypeNull; t++ {
+		if t.String() == name && t != ValueTypeNull {
+			return t, true
+		}
+	}
+	return ValueTypeUnknown, false
+}
+
+// newPattern converts a parsed case node into a Pattern
+func newPattern(node Node) (Pattern, bool) {
+	switch n := node.(type) {
+	case *Variable:
+		if n.Name == "_" {
+			return &WildcardPattern{}, true
+		}
+		if t, ok := valueTypeByName(n.Name); ok {
+			return &TypePattern{t}, true
+		}
+		return &BindPattern{n.Name}, true
+	case *ListExpression:
+		items := make([]Pattern, len(n.Items))
+		for i, item := range n.Items {
+			p, ok := newPattern(item)
+			if !ok {
+				return nil, false
+			}
+			items[i] = p
+		}
+		return &ListPattern{items}, true
+	case *BinaryExpression:
+		if n.Op == "by" {
+			return newSteppedRangePattern(n)
+		}
+		if n.Op != ".." && n.Op != "..<" {
+			return nil, false
+		}
+		low, ok1 := patternLiteral(n.Left)
+		high, ok2 := patternLiteral(n.Right)
+		if !ok1 || !ok2 {
+			return nil, false
+		}
+		return &RangePattern{low, high, n.Op == ".."}, true
+	default:
+		if v, ok := patternLiteral(node); ok {
+			return &ValuePattern{v}, true
+		}
+		return nil, false
+	}
+}
+
+// patternLiteral returns the Value of literals, including
+// newSteppedRangePattern converts `low..high by step`, with
+// integer literals, into a pattern
+func newSteppedRangePattern(n *BinaryExpression) (Pattern, bool) {
+	bounds, ok := n.Left.(*BinaryExpression)
+	if !ok || (bounds.Op != ".." && bounds.Op != "..<") {
+		return nil, false
+	}
+
+	low, ok1 := patternLiteral(bounds.Left)
+	high, ok2 := patternLiteral(bounds.Right)
+	step, ok3 := patternLiteral(n.Right)
+	from, ok4 := low.(*IntegerLiteral)
+	if !ok1 || !ok2 || !ok3 || !ok4 {
+		return nil, false
+	}
+
+	r, err := from.RangeValue(high, bounds.Op == "..")
+	if err == nil {
+		r, err = r.(*RangeLiteral).StepValue(step)
+	}
+	if err != nil {
+		return nil, false
+	}
+	return &SteppedRangePattern{r.(*RangeLiteral)}, true
+}
+
+// negative numbers
+func patternLiteral(node Node) (Value, bool) {
+	switch n := node.(type) {
+	case *UnaryExpression:
+		if n.Op != "-" {
+			return nil, false
+		}
+		v, ok := patternLiteral(n.Expr)
+		if !ok {
+			return nil, false
+		}
+		if neg, ok := v.(NegValuer); ok {
+			if res, err := neg.NegValue(); err == nil {
+				return res, true
+			}
+		}
+		return nil, false
+	case Value:
+		return n, true
+	default:
+		return nil, false
+	}
+}
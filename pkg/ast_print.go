@@ -44,10 +44,12 @@ func (n *PrintNode) Eval(ctx *Javalanche) (Value, error) {
 	switch {
 	case n.Handler == nil:
 		DefaultPrintHandler(values...)
-		return nil, nil
+		return NewNull(), nil
 	default:
-		err := n.Handler(values...)
-		return nil, err
+		if err := n.Handler(values...); err != nil {
+			return nil, err
+		}
+		return NewNull(), nil
 	}
 }
 
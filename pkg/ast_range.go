@@ -0,0 +1,263 @@
+package javalanche
+
+import (
+	"fmt"
+	"math"
+)
+
+var (
+	_ Value          = (*RangeLiteral)(nil)
+	_ Node           = (*RangeLiteral)(nil)
+	_ fmt.GoStringer = (*RangeLiteral)(nil)
+	_ fmt.Stringer   = (*RangeLiteral)(nil)
+	_ StepValuer     = (*RangeLiteral)(nil)
+	_ ContainsValuer = (*RangeLiteral)(nil)
+	_ IndexValuer    = (*RangeLiteral)(nil)
+	_ IterValuer     = (*RangeLiteral)(nil)
+)
+
+// RangeLiteral is the integers from Start to End, counting
+// by Step. They are computed when needed, so ranges of any
+// length take the same memory
+type RangeLiteral struct {
+	Start     int
+	End       int
+	Step      int
+	Inclusive bool
+}
+
+// NewRange returns the range from start to end, counting by one
+func NewRange(start, end int, inclusive bool) *RangeLiteral {
+	return &RangeLiteral{Start: start, End: end, Step: 1, Inclusive: inclusive}
+}
+
+func (n *RangeLiteral) GoString() string {
+	return fmt.Sprintf("&RangeLiteral{%v, %v, %v, %v}", n.Start, n.End, n.Step, n.Inclusive)
+}
+
+// String returns the range as written, like 1..<10 by 2
+func (n *RangeLiteral) String() string {
+	op := "..<"
+	if n.Inclusive {
+		op = ".."
+	}
+
+	s := fmt.Sprintf("%v%s%v", n.Start, op, n.End)
+	if n.Step != 1 {
+		s += fmt.Sprintf(" by %v", n.Step)
+	}
+	return s
+}
+
+func (n *RangeLiteral) Type() ValueType {
+	return ValueTypeRange
+}
+
+func (n *RangeLiteral) AsFloat64() float64 {
+	return 0
+}
+
+func (n *RangeLiteral) AsString() string {
+	return n.String()
+}
+
+func (n *RangeLiteral) AsBool() bool {
+	return n.length() > 0
+}
+
+func (n *RangeLiteral) Eval(ctx *Javalanche) (Value, error) {
+	return n, nil
+}
+
+// Equal tells if both ranges give the same integers
+func (n *RangeLiteral) Equal(v Value) bool {
+	m, ok := v.(*RangeLiteral)
+	if !ok || m.length() != n.length() {
+		return false
+	}
+
+	switch n.length() {
+	case 0:
+		return true
+	case 1:
+		return m.Start == n.Start
+	default:
+		return m.Start == n.Start && m.Step == n.Step
+	}
+}
+
+// Len returns the number of integers in the range, failing
+// if there are more than an int holds
+func (n *RangeLiteral) Len() (int, error) {
+	length := n.length()
+	if length > math.MaxInt {
+		return 0, fmt.Errorf("length of %s: %w", n, errTooLarge)
+	}
+	return int(length), nil
+}
+
+// length returns the number of integers in the range, which
+// can be more than an int holds
+func (n *RangeLiteral) length() uint64 {
+	d, ok := n.span()
+	if !ok {
+		return 0
+	}
+
+	if count := d / n.stepSize(); count < math.MaxUint64 {
+		return count + 1
+	}
+	return math.MaxUint64
+}
+
+// span returns the distance from the start to the last integer
+// the range can reach, or false if there is none
+func (n *RangeLiteral) span() (uint64, bool) {
+	var d uint64
+	switch {
+	case n.Step > 0 && n.End >= n.Start:
+		d = uint64(n.End) - uint64(n.Start)
+	case n.Step < 0 && n.End <= n.Start:
+		d = uint64(n.Start) - uint64(n.End)
+	default:
+		return 0, false
+	}
+
+	if !n.Inclusive {
+		if d == 0 {
+			return 0, false
+		}
+		d--
+	}
+	return d, true
+}
+
+// stepSize returns the absolute value of the step
+func (n *RangeLiteral) stepSize() uint64 {
+	if n.Step < 0 {
+		return uint64(-(n.Step + 1)) + 1
+	}
+	return uint64(n.Step)
+}
+
+// StepValue returns the range counting by the given integer
+func (n *RangeLiteral) StepValue(v Value) (Value, error) {
+	step, ok := v.(*IntegerLiteral)
+	switch {
+	case !ok && isBigInteger(v):
+		return nil, fmt.Errorf("step %v: %w", v, errTooLarge)
+	case !ok:
+		return nil, errNotInteger
+	case step.Value == 0:
+		return nil, errZeroStep
+	}
+
+	r := *n
+	r.Step = step.Value
+	return &r, nil
+}
+
+// ContainsValue tells if the number is one of the integers
+// of the range
+func (n *RangeLiteral) ContainsValue(v Value) (Value, error) {
+	if !isNumber(v) {
+		return NewBoolean(false), nil
+	}
+
+	i, ok := rangeInt(v)
+	if !ok {
+		return NewBoolean(false), nil
+	}
+
+	// the distance to i, in the direction of the step
+	to := &RangeLiteral{Start: n.Start, End: i, Step: n.Step, Inclusive: true}
+	d, ok := to.span()
+	found := ok && d%n.stepSize() == 0 && d/n.stepSize() < n.length()
+	return NewBoolean(found), nil
+}
+
+// rangeInt returns the number as an int, if it's a whole
+// number an int holds
+func rangeInt(v Value) (int, bool) {
+	if i, ok := v.(*IntegerLiteral); ok {
+		return i.Value, true
+	}
+
+	f := v.AsFloat64()
+	if f != math.Trunc(f) || f < math.MinInt64 || f >= math.MaxInt64 {
+		return 0, false
+	}
+	return int(f), true
+}
+
+// IndexValue returns the integer at the given position.
+// Ranges can have more integers than an int holds, so the
+// position is resolved in uint64
+func (n *RangeLiteral) IndexValue(v Value) (Value, error) {
+	i, ok := v.(*IntegerLiteral)
+	switch {
+	case !ok && isBigInteger(v):
+		// further than an int can count
+		return nil, errIndexRange
+	case !ok:
+		return nil, errNotInteger
+	}
+
+	index, length := uint64(i.Value), n.length()
+	if i.Value < 0 {
+		// wraps beyond the length when too far back
+		index = length - (uint64(-(i.Value + 1)) + 1)
+	}
+	if index >= length {
+		return nil, errIndexRange
+	}
+
+	// it may overflow on the way, but not the result
+	return NewInteger(n.Start + int(index)*n.Step), nil
+}
+
+// reversed returns the range counting the same integers from
+// the last one back to the first, or false when the step
+// can't be negated
+func (n *RangeLiteral) reversed() (*RangeLiteral, bool) {
+	length := n.length()
+	switch {
+	case length == 0:
+		return &RangeLiteral{Start: n.Start, End: n.Start, Step: 1}, true
+	case n.Step == math.MinInt:
+		return nil, false
+	}
+
+	// it may overflow on the way, but not the result
+	last := n.Start + int(length-1)*n.Step
+	return &RangeLiteral{Start: last, End: n.Start, Step: -n.Step, Inclusive: true}, true
+}
+
+// IterValue counts from the start
+func (n *RangeLiteral) IterValue() Iterator {
+	var i uint64
+	length := n.length()
+	return IteratorFunc(func() (Value, bool, error) {
+		if i >= length {
+			return nil, false, nil
+		}
+		i++
+		return NewInteger(n.Start + int(i-1)*n.Step), true, nil
+	})
+}
+
+// sliceBounds converts the range into the bounds of a slice
+func (n *RangeLiteral) sliceBounds() (Value, Value, error) {
+	if n.Step != 1 {
+		return nil, nil, errStepSlice
+	}
+
+	if !n.Inclusive {
+		return NewInteger(n.Start), NewInteger(n.End), nil
+	}
+	if n.End == -1 || n.End == math.MaxInt {
+		// up to the last
+		return NewInteger(n.Start), nil, nil
+	}
+	return NewInteger(n.Start), NewInteger(n.End + 1), nil
+}
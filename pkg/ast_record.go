@@ -0,0 +1,193 @@
+package javalanche
+
+import (
+	"fmt"
+	"strings"
+)
+
+var (
+	_ Value          = (*RecordType)(nil)
+	_ Node           = (*RecordType)(nil)
+	_ CallValuer     = (*RecordType)(nil)
+	_ fmt.GoStringer = (*RecordType)(nil)
+	_ fmt.Stringer   = (*RecordType)(nil)
+
+	_ Value          = (*RecordLiteral)(nil)
+	_ Node           = (*RecordLiteral)(nil)
+	_ FieldValuer    = (*RecordLiteral)(nil)
+	_ SetFieldValuer = (*RecordLiteral)(nil)
+	_ fmt.GoStringer = (*RecordLiteral)(nil)
+	_ fmt.Stringer   = (*RecordLiteral)(nil)
+
+	_ Node           = (*TypeNode)(nil)
+	_ fmt.GoStringer = (*TypeNode)(nil)
+	_ fmt.Stringer   = (*TypeNode)(nil)
+)
+
+// RecordType is a named list of fields. Calling it
+// creates a record with a value for each field
+type RecordType struct {
+	Name   string
+	Fields []string
+}
+
+// NewRecordType returns a record type with the given fields
+func NewRecordType(name string, fields ...string) *RecordType {
+	return &RecordType{Name: name, Fields: fields}
+}
+
+func (n *RecordType) GoString() string {
+	return fmt.Sprintf("NewRecordType(%q, %q)", n.Name, n.Fields)
+}
+
+func (n *RecordType) String() string {
+	return fmt.Sprintf("%s(%s)", n.Name, strings.Join(n.Fields, ", "))
+}
+
+func (n *RecordType) Type() ValueType {
+	return ValueTypeFunction
+}
+
+func (n *RecordType) AsFloat64() float64 {
+	return 0
+}
+
+func (n *RecordType) AsString() string {
+	return n.String()
+}
+
+func (n *RecordType) AsBool() bool {
+	return true
+}
+
+func (n *RecordType) Eval(ctx *Javalanche) (Value, error) {
+	return n, nil
+}
+
+func (n *RecordType) Equal(v Value) bool {
+	return n == v
+}
+
+// CallValue creates a record, taking the fields in order
+func (n *RecordType) CallValue(ctx *Javalanche, args ...Value) (Value, error) {
+	if len(args) != len(n.Fields) {
+		return nil, fmt.Errorf("%s: expects %v fields, got %v",
+			n.Name, len(n.Fields), len(args))
+	}
+
+	values := make([]Value, len(args))
+	copy(values, args)
+	return &RecordLiteral{RecordType: n, Values: values}, nil
+}
+
+// fieldIndex returns the position of a field, or -1
+func (n *RecordType) fieldIndex(name string) int {
+	for i, field := range n.Fields {
+		if field == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// RecordLiteral is an instance of a RecordType. Records
+// are shared, so changing a field is seen by every
+// variable holding the record
+type RecordLiteral struct {
+	RecordType *RecordType
+	Values     []Value
+}
+
+func (n *RecordLiteral) GoString() string {
+	s := make([]string, len(n.Values))
+	for i, v := range n.Values {
+		s[i] = fmt.Sprintf("%#v", v)
+	}
+	return fmt.Sprintf("&RecordLiteral{%#v, %s}", n.RecordType, strings.Join(s, ", "))
+}
+
+// String returns the record with its fields named, like
+// Point(x: 1, y: 2)
+func (n *RecordLiteral) String() string {
+	s := make([]string, len(n.Values))
+	for i, v := range n.Values {
+		s[i] = fmt.Sprintf("%s: %s", n.RecordType.Fields[i], literalString(v))
+	}
+	return fmt.Sprintf("%s(%s)", n.RecordType.Name, strings.Join(s, ", "))
+}
+
+func (n *RecordLiteral) Type() ValueType {
+	return ValueTypeRecord
+}
+
+func (n *RecordLiteral) AsFloat64() float64 {
+	return 0
+}
+
+func (n *RecordLiteral) AsString() string {
+	return n.String()
+}
+
+func (n *RecordLiteral) AsBool() bool {
+	return true
+}
+
+func (n *RecordLiteral) Eval(ctx *Javalanche) (Value, error) {
+	return n, nil
+}
+
+// Equal compares records of the same type field by field
+func (n *RecordLiteral) Equal(v Value) bool {
+	m, ok := v.(*RecordLiteral)
+	if !ok || m.RecordType != n.RecordType {
+		return false
+	}
+
+	for i, val := range n.Values {
+		if !val.Equal(m.Values[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func (n *RecordLiteral) FieldValue(name string) (Value, error) {
+	i := n.RecordType.fieldIndex(name)
+	if i < 0 {
+		return nil, fmt.Errorf("%s has no field %q", n.RecordType.Name, name)
+	}
+	return n.Values[i], nil
+}
+
+func (n *RecordLiteral) SetFieldValue(name string, v Value) error {
+	i := n.RecordType.fieldIndex(name)
+	if i < 0 {
+		return fmt.Errorf("%s has no field %q", n.RecordType.Name, name)
+	}
+	n.Values[i] = v
+	return nil
+}
+
+// TypeNode represents type Name(field, ...)
+type TypeNode struct {
+	Name   string
+	Fields []string
+}
+
+func (n *TypeNode) GoString() string {
+	return fmt.Sprintf("&TypeNode{%q, %q}", n.Name, n.Fields)
+}
+
+func (n *TypeNode) String() string {
+	return fmt.Sprintf("type %s(%s)", n.Name, strings.Join(n.Fields, ", "))
+}
+
+// Eval declares the record type as a variable
+// holding its constructor
+func (n *TypeNode) Eval(ctx *Javalanche) (Value, error) {
+	err := ctx.SetValue(n.Name, NewRecordType(n.Name, n.Fields...))
+	if err != nil {
+		return nil, err
+	}
+	return NewNull(), nil
+}
@@ -1,9 +1,15 @@
 package javalanche
 
+import (
+	"fmt"
+)
+
 var (
 	_ Node = (*BodyNode)(nil)
 	_ Node = (*IfElseNode)(nil)
 	_ Node = (*ForNode)(nil)
+	_ Node = (*ForInNode)(nil)
+	_ Node = (*BreakNode)(nil)
 )
 
 // BodyNode represents our body node
@@ -21,18 +27,25 @@ func (body BodyNode) Eval(ctx *Javalanche) (Value, error) {
 		}
 	}
 
-	return val, nil
+	return orNull(val), nil
 }
 
-// IfElseNode is struct of IfElse Node
+// IfElseNode is struct of IfElse Node. Pos isn't set
+// for elif
 type IfElseNode struct {
 	Condition Node
 	TrueBody  Node
 	FalseBody Node
+	Pos       Position
 }
 
 // Eval evaluates if/elif/else
 func (n *IfElseNode) Eval(ctx *Javalanche) (Value, error) {
+	val, err := n.eval(ctx)
+	return val, traced(err, "if", n.Pos)
+}
+
+func (n *IfElseNode) eval(ctx *Javalanche) (Value, error) {
 	condVal, err := n.Condition.Eval(ctx)
 	switch {
 	case err != nil:
@@ -42,7 +55,7 @@ func (n *IfElseNode) Eval(ctx *Javalanche) (Value, error) {
 	case n.FalseBody != nil:
 		return n.FalseBody.Eval(ctx)
 	default:
-		return nil, nil
+		return NewNull(), nil
 	}
 }
 
@@ -50,13 +63,23 @@ func (n *IfElseNode) Eval(ctx *Javalanche) (Value, error) {
 type ForNode struct {
 	Condition Node
 	Body      Node
+	Pos       Position
 }
 
 // Eval evaluates for loop
 func (n *ForNode) Eval(ctx *Javalanche) (Value, error) {
-	var val Value
+	val, err := n.eval(ctx)
+	return val, traced(err, "for", n.Pos)
+}
+
+func (n *ForNode) eval(ctx *Javalanche) (Value, error) {
+	var val Value = NewNull()
 
 	for {
+		if err := ctx.interrupted(); err != nil {
+			return nil, err
+		}
+
 		condVal, err := n.Condition.Eval(ctx)
 		switch {
 		case err != nil:
@@ -67,13 +90,135 @@ func (n *ForNode) Eval(ctx *Javalanche) (Value, error) {
 			return val, nil
 		case n.Body == nil:
 			// no body, break to prevent infinite loops
-			return nil, nil
+			return NewNull(), nil
 		default:
 			// body
-			val, err = n.Body.Eval(ctx)
-			if err != nil {
+			v, err := n.Body.Eval(ctx)
+			switch {
+			case err == errBreak:
+				return val, nil
+			case err != nil:
 				return nil, err
 			}
+			val = v
+		}
+	}
+}
+
+// ForInNode represents for name in values body end
+type ForInNode struct {
+	Name   string
+	Values Node
+	Body   Node
+	Pos    Position
+}
+
+// Eval sets the variable to each of the values in turn
+// and evaluates the body
+func (n *ForInNode) Eval(ctx *Javalanche) (Value, error) {
+	val, err := n.eval(ctx)
+	return val, traced(err, "for "+n.Name+" in", n.Pos)
+}
+
+func (n *ForInNode) eval(ctx *Javalanche) (Value, error) {
+	values, err := n.Values.Eval(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	iter, ok := values.(IterValuer)
+	if !ok {
+		return nil, typeErrorf("can't iterate over %s", values)
+	}
+
+	var val Value = NewNull()
+	next := iter.IterValue()
+	defer next.Close()
+
+	for {
+		if err := ctx.interrupted(); err != nil {
+			return nil, err
+		}
+
+		v, ok, err := next.Next()
+		switch {
+		case err != nil:
+			return nil, err
+		case !ok:
+			return val, nil
+		}
+
+		if err := ctx.SetValue(n.Name, v); err != nil {
+			return nil, err
+		}
+		if n.Body == nil {
+			continue
+		}
+
+		v, err = n.Body.Eval(ctx)
+		switch {
+		case err == errBreak:
+			return val, nil
+		case err != nil:
+			return nil, err
+		}
+		val = v
+	}
+}
+
+// BreakNode represents break, leaving the innermost loop
+type BreakNode struct{}
+
+func (n *BreakNode) String() string {
+	return "break"
+}
+
+// Eval stops the loop by returning errBreak, which loops
+// catch and functions report
+func (n *BreakNode) Eval(ctx *Javalanche) (Value, error) {
+	return nil, errBreak
+}
+
+// maxCollectedItems limits the values collected from an
+// IterValuer, as ranges and generators can be endless
+const maxCollectedItems = 1 << 24
+
+// iterValues collects all the values of an IterValuer
+func iterValues(ctx *Javalanche, iter IterValuer) ([]Value, error) {
+	var values []Value
+	err := forEach(ctx, iter.IterValue(), func(v Value) (bool, error) {
+		if len(values) >= maxCollectedItems {
+			return false, fmt.Errorf("more than %v items: %w", maxCollectedItems, errTooLarge)
+		}
+		values = append(values, v)
+		return true, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
+// forEach calls fn with each value until it returns false,
+// fails or the script is interrupted, and closes the Iterator
+func forEach(ctx *Javalanche, next Iterator, fn func(v Value) (bool, error)) error {
+	defer next.Close()
+
+	for {
+		if err := ctx.interrupted(); err != nil {
+			return err
+		}
+
+		v, ok, err := next.Next()
+		switch {
+		case err != nil:
+			return err
+		case !ok:
+			return nil
+		}
+
+		if more, err := fn(v); err != nil || !more {
+			return err
 		}
 	}
 }
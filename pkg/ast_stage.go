@@ -75,6 +75,10 @@ func NewStageToken(token *Token) (StageNode, bool) {
 // Stage represents stage
 type Stage struct {
 	nodes []StageNode
+
+	// consts are the globals declared constant so far,
+	// to reject assigning to them before running
+	consts map[string]bool
 }
 
 // Reset sets pairs to 0
@@ -94,7 +98,7 @@ func (s Stage) IsEmpty() bool {
 
 // replaceRange replaces what's between positions from to until
 // with the given Node
-func (s *Stage) replaceRange(node Node, from, until int) {
+func (s *Stage) replaceRange(node Node, from, until int) error {
 	before := s.nodes[0:from]
 	after := s.nodes[until+1:]
 
@@ -106,31 +110,31 @@ func (s *Stage) replaceRange(node Node, from, until int) {
 		nodes := append(before, n)
 		nodes = append(nodes, after...)
 		s.nodes = nodes
-		return
+		return nil
 	}
 
-	panic("unreachable")
+	return &ErrInvalidToken{Reason: "nothing to replace with"}
 }
 
-// AppendTokens appends tokens to stage
-func (s *Stage) AppendTokens(tokens ...*Token) {
+// AppendTokens appends tokens to stage, failing on leaf
+// tokens with invalid values
+func (s *Stage) AppendTokens(tokens ...*Token) error {
 	for _, token := range tokens {
 		switch {
 		case isLeafToken(token):
 			// convert token of leaf Node immediatelly
 			leaf, err := parseLeaf(token)
-			switch {
-			case err != nil:
-				panic("unreachable")
-			default:
-				s.AppendNodes(leaf)
+			if err != nil {
+				return err
 			}
+			s.AppendNodes(leaf)
 		default:
 			if n, ok := NewStageToken(token); ok {
 				s.nodes = append(s.nodes, n)
 			}
 		}
 	}
+	return nil
 }
 
 // AppendNodes appends nodes to stage
@@ -150,7 +154,11 @@ func (s *Stage) getNodeBefore(i int) (Node, error) {
 	}
 
 	// nothing before the first
-	return nil, &ErrInvalidToken{}
+	t, _ := s.nodes[i].Token()
+	return nil, &ErrInvalidToken{
+		Token:  t,
+		Reason: "missing value before",
+	}
 }
 
 // getNodeAfter returns the Node immediatelly after the given
@@ -176,6 +184,11 @@ func (s *Stage) Parse() (Node, error) {
 		case 0:
 			return nil, ErrMoreData
 		case 1:
+			if t, ok := s.nodes[0].Token(); ok && t.Is(Keyword, "try") {
+				// the only block opened by a lone keyword
+				return nil, ErrMoreData
+			}
+
 			// single node
 			node, err := s.nodes[0].Parse()
 
@@ -190,6 +203,7 @@ func (s *Stage) Parse() (Node, error) {
 			default:
 				// successfully parsed our only pair
 				s.Reset()
+				s.addConsts(node)
 				return node, nil
 			}
 		default:
@@ -198,60 +212,862 @@ func (s *Stage) Parse() (Node, error) {
 			switch {
 			case err != nil:
 				// unbalanced or incomplete
-				return nil, err
 			case found:
 				// brackets
 				err = s.parseBracketed(start, end)
-				if err != nil {
-					// bad bracketed
-					return nil, err
-				}
 			default:
 				err = s.parseUnbracketed()
-				if err != nil {
-					// bad unbracketed or incomplete
-					return nil, err
+			}
+
+			switch {
+			case err == ErrMoreData:
+				// wait for more data
+				return nil, err
+			case err != nil:
+				// parseError, reset and report
+				s.Reset()
+				return nil, err
+			}
+		}
+	}
+}
+
+func (s *Stage) parseBracketed(start, end int) error {
+	var result Node
+
+	switch open, _ := s.nodes[start].Token(); {
+	case open.Is(LeftBracket):
+		return s.parseSquareBracketed(start, end)
+	case open.Is(LeftBrace):
+		return s.parseFields(start, end)
+	}
+
+	if s.isMemberOperatorAt(start - 2) {
+		// field access binds tighter than calls
+		return s.parseMember(0, start-2)
+	}
+
+	if s.isLambdaArrowAt(end + 1) {
+		return s.parseLambdaParams(start, end)
+	}
+
+	if s.isCallee(start - 1) {
+		return s.parseCall(start, end)
+	}
+
+	left := s.nodes[:start]
+	right := s.nodes[end+1:]
+	inside := s.nodes[start+1 : end]
+
+	s.Println("parseBracketed:", "left:", left)
+	s.Println("parseBracketed:", "inside:", inside)
+	s.Println("parseBracketed:", "right:", right)
+
+	for _, n := range inside {
+		if t, ok := n.Token(); ok && t.Is(Separator) {
+			return &ErrInvalidToken{
+				Token:  t,
+				Reason: "unexpected ','",
+			}
+		}
+	}
+
+	switch len(inside) {
+	case 0:
+		// empty bracketed isn't valid
+		t, _ := s.nodes[end].Token()
+		return &ErrInvalidToken{
+			Token:  t,
+			Reason: "unexpected ')'",
+		}
+	case 1:
+		// single node
+		leaf, err := inside[0].Parse()
+		if err != nil {
+			return err
+		}
+		result = leaf
+		return s.replaceRange(result, start, end)
+	default:
+		// many nodes
+		err := s.parseRange(start+1, end)
+		if err != nil {
+			s.Println("parseBracketed:", "err:", err)
+		}
+		return nil
+	}
+}
+
+// isCallee tells if the node at the given index can be
+// called by a following parenthesis
+func (s *Stage) isCallee(i int) bool {
+	if i < 0 {
+		return false
+	}
+
+	node, _ := s.nodes[i].Node()
+	switch node.(type) {
+	case *Variable, *CallExpression, *IndexExpression, *MemberExpression, *LambdaExpression:
+		return true
+	default:
+		return false
+	}
+}
+
+// isMemberOperatorAt tells if there is a field access
+// operator at the given index
+func (s *Stage) isMemberOperatorAt(i int) bool {
+	if i < 0 || i >= len(s.nodes) {
+		return false
+	}
+
+	t, ok := s.nodes[i].Token()
+	return ok && t.Type == Operator && isMemberOperator(t.Value)
+}
+
+// isOptionalBracket tells if the square bracket at the given
+// index is written right after a `?`, as in list?[i]
+func (s *Stage) isOptionalBracket(i int) bool {
+	if i < 1 {
+		return false
+	}
+
+	q, _ := s.nodes[i-1].Token()
+	open, _ := s.nodes[i].Token()
+	return q.Is(Operator, "?") && isAdjacent(q, open)
+}
+
+// isAdjacent tells if the second token was written
+// immediately after the first
+func isAdjacent(a, b *Token) bool {
+	after := Position{a.Pos.Line, a.Pos.Column + len([]rune(a.Value))}
+	return a.Pos.IsValid() && after == b.Pos
+}
+
+// parseMember parses expr?.name. Chained accesses are
+// parsed left to right
+func (s *Stage) parseMember(start, pivot int) error {
+	for pivot-2 >= start && s.isMemberOperatorAt(pivot-2) {
+		pivot -= 2
+	}
+
+	op, _ := s.nodes[pivot].Token()
+
+	before, err := s.getNodeBefore(pivot)
+	if err != nil {
+		return err
+	}
+
+	after, err := s.getNodeAfter(pivot)
+	if err != nil {
+		return err
+	}
+
+	field, ok := after.(*Variable)
+	if !ok {
+		return &ErrInvalidToken{
+			Token:  op,
+			Reason: "expected a field name",
+		}
+	}
+
+	n := &MemberExpression{
+		Expr:     before,
+		Name:     field.Name,
+		Optional: op.Value == "?.",
+		Pos:      op.Pos,
+	}
+
+	s.Printf("parseMember: pivot:%v [%s %s %s] → %s", pivot, before, op, after, n)
+	return s.replaceRange(n, pivot-1, pivot+1)
+}
+
+// parseConditional parses cond ? a : b. The last `?` is
+// paired with the next `:`, so they nest to the right
+func (s *Stage) parseConditional(start, end int) error {
+	question, colon := -1, -1
+	for i := start; i < end; i++ {
+		t, ok := s.nodes[i].Token()
+		switch {
+		case !ok:
+			continue
+		case t.Is(Operator, "?"):
+			question, colon = i, -1
+		case t.Is(Operator, ":") && colon < 0:
+			colon = i
+		}
+	}
+
+	switch {
+	case question < 0:
+		t, _ := s.nodes[colon].Token()
+		return &ErrInvalidToken{
+			Token:  t,
+			Reason: "unexpected ':'",
+		}
+	case colon < 0:
+		t, _ := s.nodes[question].Token()
+		return &ErrInvalidToken{
+			Token:  t,
+			Reason: "missing ':'",
+		}
+	case colon != question+2:
+		t, _ := s.nodes[colon].Token()
+		return &ErrInvalidToken{
+			Token:  t,
+			Reason: "unexpected ':'",
+		}
+	}
+
+	cond, err := s.getNodeBefore(question)
+	if err != nil {
+		return err
+	}
+	then, err := s.nodes[question+1].Parse()
+	if err != nil {
+		return err
+	}
+	otherwise, err := s.getNodeAfter(colon)
+	if err != nil {
+		return err
+	}
+
+	n := &ConditionalExpression{
+		Condition: cond,
+		Then:      then,
+		Else:      otherwise,
+	}
+
+	s.Printf("parseConditional: [%s ? %s : %s] → %s", cond, then, otherwise, n)
+	return s.replaceRange(n, question-1, colon+1)
+}
+
+// commaList walks from the node at the given index in the
+// given direction, collecting nodes separated by commas
+func (s *Stage) commaList(start, end, at, step int) ([]Node, int) {
+	var nodes []Node
+	for i := at; i >= start && i < end; i += 2 * step {
+		node, ok := s.nodes[i].Node()
+		if !ok {
+			break
+		}
+		nodes = append(nodes, node)
+		at = i
+
+		next := i + step
+		if next < start || next >= end {
+			break
+		}
+		if t, _ := s.nodes[next].Token(); !t.Is(Separator) {
+			break
+		}
+	}
+	return nodes, at
+}
+
+// isMultipleAssign tells if there are commas around the `=`
+// at pivot
+func (s *Stage) isMultipleAssign(start, end, pivot int) bool {
+	for _, i := range []int{pivot - 2, pivot + 2} {
+		if i >= start && i < end {
+			if t, _ := s.nodes[i].Token(); t.Is(Separator) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// parseMultipleAssign parses a, b = c, d and a, b = list
+func (s *Stage) parseMultipleAssign(start, end, pivot int) error {
+	op, _ := s.nodes[pivot].Token()
+
+	targets, first := s.commaList(start, end, pivot-1, -1)
+	values, last := s.commaList(start, end, pivot+1, 1)
+
+	switch {
+	case len(targets) == 0:
+		return &ErrInvalidToken{
+			Token:  op,
+			Reason: "missing variables",
+		}
+	case len(values) == 0:
+		return ErrMoreData
+	case len(values) > 1 && len(values) != len(targets):
+		return &ErrInvalidToken{
+			Token:  op,
+			Reason: assignMismatch(len(targets), len(values)).Error(),
+		}
+	}
+
+	// targets were collected backwards
+	for i, j := 0, len(targets)-1; i < j; i, j = i+1, j-1 {
+		targets[i], targets[j] = targets[j], targets[i]
+	}
+
+	for _, target := range targets {
+		if _, ok := target.(SetValuer); !ok {
+			return &ErrInvalidToken{
+				Token:  op,
+				Reason: fmt.Sprintf("can't assign to %s", target),
+			}
+		}
+		if err := s.checkAssignable(op, target); err != nil {
+			return err
+		}
+	}
+
+	n := &AssignExpression{Targets: targets, Values: values, Pos: op.Pos}
+
+	s.Printf("parseMultipleAssign: [%s] → %s", op, n)
+	return s.replaceRange(n, first, last)
+}
+
+// addConsts remembers the constants a statement declares.
+// Only global ones, as those in functions are local
+func (s *Stage) addConsts(node Node) {
+	n, ok := node.(*ConstNode)
+	if !ok {
+		return
+	}
+
+	if s.consts == nil {
+		s.consts = make(map[string]bool)
+	}
+	for _, name := range n.Names {
+		s.consts[name] = true
+	}
+}
+
+// checkAssignable rejects assigning to variables declared
+// constant earlier
+func (s *Stage) checkAssignable(op *Token, target Node) error {
+	names, _ := assignedNames(target)
+	for _, name := range names {
+		if s.consts[name] {
+			return &ErrInvalidToken{
+				Token:  op,
+				Reason: fmt.Sprintf("can't assign to constant %s", name),
+			}
+		}
+	}
+	return nil
+}
+
+// hasOperator tells if the operator appears in the range
+func (s *Stage) hasOperator(start, end int, op string) bool {
+	for _, n := range s.nodes[start:end] {
+		if t, ok := n.Token(); ok && t.Is(Operator, op) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseAnnotation parses name: type
+func (s *Stage) parseAnnotation(pivot int) error {
+	op, _ := s.nodes[pivot].Token()
+
+	before, err := s.getNodeBefore(pivot)
+	if err != nil {
+		return err
+	}
+	after, err := s.getNodeAfter(pivot)
+	if err != nil {
+		return err
+	}
+
+	v, ok := before.(*Variable)
+	if !ok {
+		return &ErrInvalidToken{
+			Token:  op,
+			Reason: fmt.Sprintf("can't annotate %s", before),
+		}
+	}
+
+	var typ ValueType
+	if name, ok := after.(*Variable); ok {
+		typ, _ = valueTypeByName(name.Name)
+	}
+	if typ == ValueTypeUnknown {
+		return &ErrInvalidToken{
+			Token:  op,
+			Reason: fmt.Sprintf("unknown type %s", after),
+		}
+	}
+
+	n := &AnnotatedVariable{Variable: v, Type: typ}
+
+	s.Printf("parseAnnotation: [%s %s %s] → %s", before, op, after, n)
+	return s.replaceRange(n, pivot-1, pivot+1)
+}
+
+// parseResultAnnotation parses name(param, ...) -> type, the
+// signature of a function with the type it returns
+func (s *Stage) parseResultAnnotation(pivot int) error {
+	op, _ := s.nodes[pivot].Token()
+
+	before, err := s.getNodeBefore(pivot)
+	if err != nil {
+		return err
+	}
+	after, err := s.getNodeAfter(pivot)
+	if err != nil {
+		return err
+	}
+
+	var typ ValueType
+	if name, ok := after.(*Variable); ok {
+		typ, _ = valueTypeByName(name.Name)
+	}
+	if typ == ValueTypeUnknown {
+		return &ErrInvalidToken{
+			Token:  op,
+			Reason: fmt.Sprintf("unknown type %s", after),
+		}
+	}
+
+	n := &ResultAnnotation{Signature: before, Type: typ}
+
+	s.Printf("parseResultAnnotation: [%s %s %s] → %s", before, op, after, n)
+	return s.replaceRange(n, pivot-1, pivot+1)
+}
+
+// parseConstKeyword parses const name = value
+func (s *Stage) parseConstKeyword(at int) error {
+	t, _ := s.nodes[at].Token()
+
+	assign, err := s.getNodeAfter(at)
+	if err != nil {
+		return err
+	}
+
+	names, ok := constNames(assign)
+	if !ok {
+		return &ErrInvalidToken{
+			Token:  t,
+			Reason: "expected assignment",
+		}
+	}
+
+	return s.replaceRange(&ConstNode{Names: names, Assign: assign}, at, at+1)
+}
+
+// parseTypeKeyword parses type Name(field, ...)
+func (s *Stage) parseTypeKeyword(at int) error {
+	t, _ := s.nodes[at].Token()
+
+	decl, err := s.getNodeAfter(at)
+	if err != nil {
+		return err
+	}
+
+	name, fields, err := s.parseSignature(t, decl, "Name(field, ...)", "field")
+	if err != nil {
+		return err
+	}
+
+	return s.replaceRange(&TypeNode{Name: name, Fields: fields}, at, at+1)
+}
+
+// parseYieldKeyword parses yield value
+func (s *Stage) parseYieldKeyword(at int) error {
+	value, err := s.getNodeAfter(at)
+	if err != nil {
+		return err
+	}
+
+	return s.replaceRange(&YieldNode{Value: value}, at, at+1)
+}
+
+// parseThrowKeyword parses throw value
+func (s *Stage) parseThrowKeyword(at int) error {
+	t, _ := s.nodes[at].Token()
+
+	value, err := s.getNodeAfter(at)
+	if err != nil {
+		return err
+	}
+
+	return s.replaceRange(&ThrowNode{Value: value, Pos: t.Pos}, at, at+1)
+}
+
+// parseSignature parses the name(a, b) declared after a
+// keyword, which must be an assignable name followed by
+// distinct names
+func (s *Stage) parseSignature(t *Token, decl Node, expected, kind string) (string, []string, error) {
+	call, ok := decl.(*CallExpression)
+	if ok {
+		_, ok = call.Callee.(*Variable)
+	}
+	if !ok {
+		return "", nil, &ErrInvalidToken{
+			Token:  t,
+			Reason: "expected " + expected,
+		}
+	}
+
+	names, err := paramNames(t, call.Args, kind)
+	if err != nil {
+		return "", nil, err
+	}
+
+	if err := s.checkAssignable(t, call.Callee); err != nil {
+		return "", nil, err
+	}
+	return call.Callee.(*Variable).Name, names, nil
+}
+
+// paramNames returns the names of a list of distinct variables
+func paramNames(t *Token, nodes []Node, kind string) ([]string, error) {
+	names := make([]string, len(nodes))
+	for i, node := range nodes {
+		v, ok := node.(*Variable)
+		if !ok {
+			return nil, &ErrInvalidToken{
+				Token:  t,
+				Reason: fmt.Sprintf("invalid %s %s", kind, node),
+			}
+		}
+		for _, name := range names[:i] {
+			if name == v.Name {
+				return nil, &ErrInvalidToken{
+					Token:  t,
+					Reason: fmt.Sprintf("duplicate %s %s", kind, name),
 				}
 			}
 		}
+		names[i] = v.Name
+	}
+	return names, nil
+}
+
+// paramTypes takes the types out of name: type parameters,
+// returning nil types when none is annotated
+func paramTypes(nodes []Node) ([]Node, []ValueType) {
+	var types []ValueType
+	params := make([]Node, len(nodes))
+	for i, node := range nodes {
+		params[i] = node
+		if v, ok := node.(*AnnotatedVariable); ok {
+			if types == nil {
+				types = make([]ValueType, len(nodes))
+			}
+			params[i], types[i] = v.Variable, v.Type
+		}
+	}
+	return params, types
+}
+
+// parseFnKeyword parses fn name(param: type, ...) -> type body end,
+// where the types are optional
+func (s *Stage) parseFnKeyword(start, end int) error {
+	t, _ := s.nodes[start].Token()
+
+	decl, _ := s.nodes[start+1].Node()
+	result := ValueTypeUnknown
+	if r, ok := decl.(*ResultAnnotation); ok {
+		decl, result = r.Signature, r.Type
+	}
+
+	var types []ValueType
+	if call, ok := decl.(*CallExpression); ok {
+		signature := *call
+		signature.Args, types = paramTypes(call.Args)
+		decl = &signature
+	}
+
+	name, params, err := s.parseSignature(t, decl, "name(param, ...)", "parameter")
+	if err != nil {
+		return err
+	}
+
+	body, err := s.parseBody(t, start+2, end-1)
+	if err != nil {
+		return err
+	}
+
+	n := &FunctionNode{
+		Name:      name,
+		Params:    params,
+		Types:     types,
+		Result:    result,
+		Body:      body,
+		Generator: containsYield(body),
+		Pos:       t.Pos,
+	}
+	return s.replaceRange(n, start, end-1)
+}
+
+// parseClassKeyword parses class Name(Parent) followed by
+// field = default assignments and fn methods, and end
+func (s *Stage) parseClassKeyword(start, end int) error {
+	t, _ := s.nodes[start].Token()
+	result := &ClassNode{}
+
+	decl, _ := s.nodes[start+1].Node()
+	if v, ok := decl.(*Variable); ok {
+		// no parent
+		decl = &CallExpression{Callee: v}
+	}
+
+	name, parents, err := s.parseSignature(t, decl, "Name or Name(Parent)", "parent")
+	switch {
+	case err != nil:
+		return err
+	case len(parents) > 1:
+		return &ErrInvalidToken{
+			Token:  t,
+			Reason: "only one parent class is allowed",
+		}
+	case len(parents) == 1:
+		result.Parent = parents[0]
+	}
+	result.Name = name
+
+	body, err := s.parseBody(t, start+2, end-1)
+	if err != nil {
+		return err
+	}
+
+	for _, node := range body {
+		if fn, ok := node.(*FunctionNode); ok {
+			result.Methods = append(result.Methods, fn)
+			continue
+		}
+
+		field, value, ok := classField(node)
+		if !ok {
+			return &ErrInvalidToken{
+				Token:  t,
+				Reason: fmt.Sprintf("unexpected %s in class %s", node, name),
+			}
+		}
+		result.Fields = append(result.Fields, field)
+		result.Defaults = append(result.Defaults, value)
+	}
+
+	return s.replaceRange(result, start, end-1)
+}
+
+// classField returns the field and default value declared
+// by an assignment in a class
+func classField(node Node) (string, Node, bool) {
+	if n, ok := node.(*BinaryExpression); ok && n.Op == "=" {
+		if v, ok := n.Left.(*Variable); ok {
+			return v.Name, n.Right, true
+		}
+	}
+	return "", nil, false
+}
+
+// parseBody collects the statements of a keyword block
+func (s *Stage) parseBody(t *Token, start, end int) (BodyNode, error) {
+	body := BodyNode{}
+	for _, n := range s.nodes[start:end] {
+		node, ok := n.Node()
+		if !ok {
+			tok, _ := n.Token()
+			return nil, &ErrInvalidToken{
+				Token:  tok,
+				Reason: fmt.Sprintf("unexpected in %s", t.Value),
+			}
+		}
+		body = append(body, node)
+	}
+	return body, nil
+}
+
+// isLambdaArrowAt tells if there is a `=>` at the given index
+func (s *Stage) isLambdaArrowAt(i int) bool {
+	if i < 0 || i >= len(s.nodes) {
+		return false
+	}
+
+	t, ok := s.nodes[i].Token()
+	return ok && t.Is(Operator, lambdaOperator)
+}
+
+// parseLambdaParams parses the (param, ...) before a `=>`
+func (s *Stage) parseLambdaParams(start, end int) error {
+	open, _ := s.nodes[start].Token()
+
+	nodes, done, err := s.parseSegments(open, s.splitSegments(start, end, Separator))
+	if !done || err != nil {
+		return err
+	}
+
+	params, err := paramNames(open, nodes, "parameter")
+	if err != nil {
+		return err
+	}
+
+	return s.replaceRange(&LambdaExpression{Params: params}, start, end)
+}
+
+// parseFields parses {name, ...}, the fields assigned by
+// destructuring
+func (s *Stage) parseFields(start, end int) error {
+	open, _ := s.nodes[start].Token()
+
+	nodes, done, err := s.parseSegments(open, s.splitSegments(start, end, Separator))
+	if !done || err != nil {
+		return err
+	}
+
+	if len(nodes) == 0 {
+		close, _ := s.nodes[end].Token()
+		return &ErrInvalidToken{
+			Token:  close,
+			Reason: "unexpected '}'",
+		}
+	}
+	for i, node := range nodes {
+		if node == nil {
+			return s.unexpectedSegment(start, end, i)
+		}
+	}
+
+	names, err := paramNames(open, nodes, "field")
+	if err != nil {
+		return err
+	}
+
+	return s.replaceRange(&FieldsExpression{Names: names, Pos: open.Pos}, start, end)
+}
+
+// parseLambda parses params => body, where params is a
+// single name or a bracketed list
+func (s *Stage) parseLambda(pivot int) error {
+	op, _ := s.nodes[pivot].Token()
+
+	before, err := s.getNodeBefore(pivot)
+	if err != nil {
+		return err
+	}
+	after, err := s.getNodeAfter(pivot)
+	if err != nil {
+		return err
+	}
+
+	var n *LambdaExpression
+	switch v := before.(type) {
+	case *Variable:
+		n = &LambdaExpression{Params: []string{v.Name}}
+	case *LambdaExpression:
+		if v.Body == nil {
+			n = v
+		}
+	}
+	if n == nil {
+		return &ErrInvalidToken{
+			Token:  op,
+			Reason: fmt.Sprintf("invalid parameters %s", before),
+		}
 	}
+	n.Body = after
+
+	s.Printf("parseLambda: [%s %s %s] → %s", before, op, after, n)
+	return s.replaceRange(n, pivot-1, pivot+1)
 }
 
-func (s *Stage) parseBracketed(start, end int) error {
-	var result Node
+// parseCall parses fn(arg, ...)
+func (s *Stage) parseCall(start, end int) error {
+	open, _ := s.nodes[start].Token()
 
-	left := s.nodes[:start]
-	right := s.nodes[end+1:]
-	inside := s.nodes[start+1 : end]
+	s.PrintDetails("parseCall %v..%v", start, end)
 
-	s.Println("parseBracketed:", "left:", left)
-	s.Println("parseBracketed:", "inside:", inside)
-	s.Println("parseBracketed:", "right:", right)
+	args, done, err := s.parseSegments(open, s.splitSegments(start, end, Separator))
+	if !done || err != nil {
+		return err
+	}
 
-	switch len(inside) {
-	case 0:
-		// empty bracketed isn't valid
-		t, _ := s.nodes[end].Token()
-		return &ErrInvalidToken{
-			Token:  t,
-			Reason: "unexpected ')'",
+	for i, arg := range args {
+		if arg == nil {
+			return s.unexpectedSegment(start, end, i)
 		}
-	case 1:
-		// single node
-		leaf, err := inside[0].Parse()
-		if err != nil {
-			return err
+	}
+
+	callee, _ := s.nodes[start-1].Node()
+	return s.replaceRange(&CallExpression{Callee: callee, Args: args, Pos: open.Pos}, start-1, end)
+}
+
+// parseList parses [item, ...] list literals
+func (s *Stage) parseList(start, end int) error {
+	open, _ := s.nodes[start].Token()
+
+	s.PrintDetails("parseList %v..%v", start, end)
+
+	items, done, err := s.parseSegments(open, s.splitSegments(start, end, Separator))
+	if !done || err != nil {
+		return err
+	}
+
+	for i, item := range items {
+		if item == nil {
+			return s.unexpectedSegment(start, end, i)
 		}
-		result = leaf
-		s.replaceRange(result, start, end)
+	}
+
+	return s.replaceRange(&ListExpression{Items: items}, start, end)
+}
+
+// splitSegments returns the bounds of the segments between
+// the brackets at start and end, split on the given token type.
+// nothing between the brackets means no segments
+func (s *Stage) splitSegments(start, end int, sep TokenType) [][2]int {
+	if end == start+1 {
 		return nil
-	default:
-		// many nodes
-		err := s.parseRange(start+1, end)
-		if err != nil {
-			s.Println("parseBracketed:", "err:", err)
+	}
+
+	var segments [][2]int
+	from := start + 1
+	for i := from; i < end; i++ {
+		if t, ok := s.nodes[i].Token(); ok && t.Is(sep) {
+			segments = append(segments, [2]int{from, i})
+			from = i + 1
 		}
-		return nil
+	}
+	return append(segments, [2]int{from, end})
+}
+
+// parseSegments parses the node of each segment, nil if empty.
+// if a segment needs reducing first a single step is taken and
+// done is false, so the stage gets parsed again
+func (s *Stage) parseSegments(open *Token, segments [][2]int) ([]Node, bool, error) {
+	parts := make([]Node, len(segments))
+	for i, seg := range segments {
+		switch seg[1] - seg[0] {
+		case 0:
+			// empty
+		case 1:
+			node, err := s.nodes[seg[0]].Parse()
+			if err != nil {
+				return nil, false, err
+			}
+			parts[i] = node
+		default:
+			// reduce the segment first
+			if _, op := findHighestPrecedenceOperatorInRange(s.nodes, seg[0], seg[1]); op == nil {
+				return nil, false, &ErrInvalidToken{
+					Token:  open,
+					Reason: "missing operator inside brackets",
+				}
+			}
+			return nil, false, s.parseRange(seg[0], seg[1])
+		}
+	}
+	return parts, true, nil
+}
+
+// unexpectedSegment reports the token following the empty
+// segment i of the brackets at start and end
+func (s *Stage) unexpectedSegment(start, end, i int) error {
+	segments := s.splitSegments(start, end, Separator)
+	t, _ := s.nodes[segments[i][1]].Token()
+	return &ErrInvalidToken{
+		Token:  t,
+		Reason: fmt.Sprintf("unexpected %q", t.Value),
 	}
 }
 
@@ -278,23 +1094,25 @@ func (s *Stage) parsePrintKeyword(start, end int) error {
 		}
 	}
 
-	s.replaceRange(printNode, start, end-1)
-
-	return nil
+	return s.replaceRange(printNode, start, end-1)
 }
 
 // parseKeywords parses keywords with correct precedence
 func (s *Stage) parseKeywords(start, end int) error {
 	lastOpen := ""
 	lastOpenIndex := -1
+	lastCaseIndex := -1
 
 	s.PrintDetails("parseKeywords %v..%v", start, end)
 	for i, n := range s.nodes[start:end] {
 		if t, ok := n.Token(); ok && t.Type == Keyword {
 			switch t.Value {
-			case "if", "for", "print":
+			case "if", "for", "print", "match", "fn", "class", "try":
 				// open
 				switch {
+				case lastOpen == "case" && t.Value == "if" &&
+					s.isGuard(start+lastCaseIndex, start+i):
+					// case pattern if guard
 				case lastOpen == "":
 					// first
 					lastOpen = t.Value
@@ -318,15 +1136,31 @@ func (s *Stage) parseKeywords(start, end int) error {
 					// parse complete keyword block
 					return s.parseKeyword(start+lastOpenIndex, start+i+1)
 				}
-			case "elif", "else":
-				// elif and else can only come after if or elif
-				switch lastOpen {
-				case "print":
+			case "const":
+				// declaration of the following assignment
+				return s.parseConstKeyword(start + i)
+			case "type":
+				// declaration of a record type
+				return s.parseTypeKeyword(start + i)
+			case "yield":
+				// value of a generator
+				return s.parseYieldKeyword(start + i)
+			case "throw":
+				// error to raise
+				return s.parseThrowKeyword(start + i)
+			case "elif", "else", "case", "catch", "finally":
+				// elif and else can only come after if or elif,
+				// case and else after match or case
+				switch {
+				case lastOpen == "print":
 					// parse print command
 					return s.parsePrintKeyword(start+lastOpenIndex, start+i)
-				case "if", "elif":
+				case canFollowKeyword(lastOpen, t.Value):
 					// remember and continue
 					lastOpen = t.Value
+					if t.Value == "case" {
+						lastCaseIndex = i
+					}
 				default:
 					// unexpected
 					return &ErrInvalidToken{
@@ -363,6 +1197,14 @@ func (s *Stage) parseKeyword(start, end int) error {
 			return s.parseForKeyword(start, end)
 		case "print":
 			return s.parsePrintKeyword(start, end)
+		case "match":
+			return s.parseMatchKeyword(start, end)
+		case "fn":
+			return s.parseFnKeyword(start, end)
+		case "class":
+			return s.parseClassKeyword(start, end)
+		case "try":
+			return s.parseTryKeyword(start, end)
 		}
 	}
 
@@ -372,38 +1214,347 @@ func (s *Stage) parseKeyword(start, end int) error {
 	}
 }
 
+// canFollowKeyword tells if a branch keyword can follow the
+// last opened one
+func canFollowKeyword(last, next string) bool {
+	switch next {
+	case "elif":
+		return last == "if" || last == "elif"
+	case "else":
+		return last == "if" || last == "elif" || last == "match" || last == "case"
+	case "case":
+		return last == "match" || last == "case"
+	case "catch":
+		return last == "try"
+	case "finally":
+		return last == "try" || last == "catch"
+	default:
+		return false
+	}
+}
+
+// isGuard tells if the `if` at the given index directly
+// follows the patterns of the case at caseAt, making it
+// a guard and not a nested if
+func (s *Stage) isGuard(caseAt, at int) bool {
+	span := at - caseAt
+	if span < 2 || span%2 != 0 {
+		return false
+	}
+
+	for i := caseAt + 1; i < at; i++ {
+		t, isToken := s.nodes[i].Token()
+		switch {
+		case (i-caseAt)%2 == 1 && isToken:
+			// expected pattern
+			return false
+		case (i-caseAt)%2 == 0 && !t.Is(Separator):
+			// expected ','
+			return false
+		}
+	}
+	return true
+}
+
+// parseMatchKeyword parses match subject, its cases and
+// else, and reports cases that can't be reached
+func (s *Stage) parseMatchKeyword(start, end int) error {
+	matchToken, _ := s.nodes[start].Token()
+	result := &MatchNode{Pos: matchToken.Pos}
+
+	s.PrintDetails("parseMatchKeyword %v..%v", start, end)
+
+	subject, ok := s.nodes[start+1].Node()
+	if !ok {
+		return &ErrInvalidToken{
+			Token:  matchToken,
+			Reason: "missing subject",
+		}
+	}
+	result.Subject = subject
+
+	var caseTokens []*Token
+	var body, elseBody *BodyNode
+
+	for i := start + 2; i < end-1; i++ {
+		n := s.nodes[i]
+		t, isToken := n.Token()
+		switch {
+		case t.Is(Keyword, "case"):
+			patterns, guard, next, err := s.parseCasePatterns(i, end-1)
+			if err != nil {
+				return err
+			}
+			c := &MatchCase{Patterns: patterns, Guard: guard}
+			result.Cases = append(result.Cases, c)
+			caseTokens = append(caseTokens, t)
+			body = &c.Body
+			i = next - 1
+		case t.Is(Keyword, "else"):
+			elseBody = &BodyNode{}
+			body = elseBody
+		case isToken, body == nil:
+			// tokens left, or statements before the first case
+			return &ErrInvalidToken{
+				Token:  matchToken,
+				Reason: "expected case",
+			}
+		default:
+			node, _ := n.Node()
+			*body = append(*body, node)
+		}
+	}
+
+	if err := checkMatchCases(result.Cases, caseTokens); err != nil {
+		return err
+	}
+
+	if elseBody != nil {
+		if hasCatchAll(result.Cases) {
+			return &ErrInvalidToken{
+				Token:  matchToken,
+				Reason: "unreachable else",
+			}
+		}
+		result.Else = *elseBody
+	}
+
+	return s.replaceRange(result, start, end-1)
+}
+
+// parseCasePatterns parses the comma separated patterns
+// and the optional guard of the case at the given index.
+// It returns the index where the body starts
+func (s *Stage) parseCasePatterns(at, end int) ([]Pattern, Node, int, error) {
+	caseToken, _ := s.nodes[at].Token()
+
+	var patterns []Pattern
+	i := at + 1
+	for {
+		var node Node
+		if i < end {
+			node, _ = s.nodes[i].Node()
+		}
+		if node == nil {
+			return nil, nil, 0, &ErrInvalidToken{
+				Token:  caseToken,
+				Reason: "missing pattern",
+			}
+		}
+
+		p, ok := newPattern(node)
+		if !ok {
+			return nil, nil, 0, &ErrInvalidToken{
+				Token:  caseToken,
+				Reason: fmt.Sprintf("invalid pattern %s", node),
+			}
+		}
+		patterns = append(patterns, p)
+
+		i++
+		if t, _ := s.nodes[i].Token(); !t.Is(Separator) {
+			break
+		}
+		i++
+	}
+
+	var guard Node
+	if t, _ := s.nodes[i].Token(); t.Is(Keyword, "if") {
+		node, ok := s.nodes[i+1].Node()
+		if !ok {
+			return nil, nil, 0, &ErrInvalidToken{
+				Token:  t,
+				Reason: "missing guard",
+			}
+		}
+		guard = node
+		i += 2
+	}
+
+	return patterns, guard, i, nil
+}
+
+// checkMatchCases reports patterns that are already covered
+// by earlier ones, which would never be chosen
+func checkMatchCases(cases []*MatchCase, tokens []*Token) error {
+	for i, c := range cases {
+		for j, q := range c.Patterns {
+			// earlier alternatives of the same case
+			for _, p := range c.Patterns[:j] {
+				if coversPattern(p, q) {
+					return unreachableCase(tokens[i], p, q)
+				}
+			}
+
+			// earlier cases, unless guarded
+			for _, prev := range cases[:i] {
+				if prev.Guard != nil {
+					continue
+				}
+				for _, p := range prev.Patterns {
+					if coversPattern(p, q) {
+						return unreachableCase(tokens[i], p, q)
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// unreachableCase reports the pattern q covered by p
+func unreachableCase(t *Token, p, q Pattern) error {
+	reason := fmt.Sprintf("unreachable case %s, covered by %s", q, p)
+	if _, ok := q.(*ValuePattern); ok && p.String() == q.String() {
+		reason = fmt.Sprintf("duplicate case %s", q)
+	}
+
+	return &ErrInvalidToken{
+		Token:  t,
+		Reason: reason,
+	}
+}
+
+// hasCatchAll tells if an unguarded case matches anything
+func hasCatchAll(cases []*MatchCase) bool {
+	for _, c := range cases {
+		if c.Guard != nil {
+			continue
+		}
+		for _, p := range c.Patterns {
+			if isCatchAll(p) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 // parseForKeyword parses loops
 func (s *Stage) parseForKeyword(start, end int) error {
 	var body BodyNode
 	var result ForNode
 
+	if t, ok := s.nodes[start].Token(); ok {
+		result.Pos = t.Pos
+	}
+
 	s.PrintDetails("parseForKetword %v..%v", start, end)
 	for _, n := range s.nodes[start+1 : end] {
 		if result.Condition == nil {
 			// needs condition
 			cond, ok := n.Node()
 			if !ok {
-				return &ErrInvalidToken{}
+				return unexpectedNode(n, "expected a condition")
 			}
 			result.Condition = cond
-		} else if t, ok := n.Token(); ok && t.Type == Keyword {
-			switch t.Value {
-			case "end":
-				result.Body = body
-				// done
-				s.replaceRange(&result, start, end-1)
-				return nil
-			default:
-				panic("unreachable")
-			}
+		} else if t, ok := n.Token(); ok && t.Is(Keyword, "end") {
+			result.Body = body
+			// done
+			return s.replaceRange(forNode(&result), start, end-1)
 		} else if node, ok := n.Node(); ok {
 			body = append(body, node)
 		} else {
-			panic("unreachable")
+			return unexpectedNode(n, "unexpected in for")
+		}
+	}
+
+	return unexpectedNode(s.nodes[start], "missing end")
+}
+
+// unexpectedNode reports a token, or a node, the parser
+// can't use
+func unexpectedNode(n StageNode, reason string) error {
+	t, ok := n.Token()
+	if !ok {
+		reason = fmt.Sprintf("%s, got %s", reason, n)
+	}
+	return &ErrInvalidToken{
+		Token:  t,
+		Reason: reason,
+	}
+}
+
+// forNode turns `for x in values` into a loop over the values
+func forNode(n *ForNode) Node {
+	if in, ok := n.Condition.(*BinaryExpression); ok && in.Op == "in" {
+		if v, ok := in.Left.(*Variable); ok {
+			return &ForInNode{Name: v.Name, Values: in.Right, Body: n.Body, Pos: n.Pos}
+		}
+	}
+	return n
+}
+
+// parseTryKeyword parses try body, followed by catch name body
+// and/or finally body, and end
+func (s *Stage) parseTryKeyword(start, end int) error {
+	t, _ := s.nodes[start].Token()
+	result := &TryNode{Pos: t.Pos}
+	section := "try"
+	body := BodyNode{}
+	caught := false
+
+	// flush stores the body of the current section
+	flush := func() {
+		switch section {
+		case "try":
+			result.Body = body
+		case "catch":
+			result.Catch = body
+		default:
+			result.Finally = body
+		}
+		body = BodyNode{}
+	}
+
+	s.PrintDetails("parseTryKeyword %v..%v", start, end)
+	for _, n := range s.nodes[start+1 : end-1] {
+		if k, ok := n.Token(); ok && k.Type == Keyword {
+			// catch or finally, checked by parseKeywords
+			flush()
+			section = k.Value
+			caught = caught || section == "catch"
+			continue
+		}
+
+		node, ok := n.Node()
+		switch {
+		case !ok:
+			k, _ := n.Token()
+			return &ErrInvalidToken{
+				Token:  k,
+				Reason: "unexpected in try",
+			}
+		case section == "catch" && result.Name == "":
+			// the name of the error comes first
+			v, ok := node.(*Variable)
+			if !ok {
+				return &ErrInvalidToken{
+					Token:  t,
+					Reason: fmt.Sprintf("expected a name after catch, got %s", node),
+				}
+			}
+			result.Name = v.Name
+		default:
+			body = append(body, node)
+		}
+	}
+	flush()
+
+	switch {
+	case caught && result.Name == "":
+		return &ErrInvalidToken{
+			Token:  t,
+			Reason: "expected a name after catch",
+		}
+	case !caught && result.Finally == nil:
+		return &ErrInvalidToken{
+			Token:  t,
+			Reason: "expected catch or finally",
 		}
 	}
 
-	panic("unreachable")
+	return s.replaceRange(result, start, end-1)
 }
 
 // parseIfKeyword parses if logic
@@ -413,6 +1564,10 @@ func (s *Stage) parseIfKeyword(start, end int) error {
 	result := &IfElseNode{}
 	n1 := result
 
+	if t, ok := s.nodes[start].Token(); ok {
+		result.Pos = t.Pos
+	}
+
 	s.PrintDetails("parseIfKeywords %v..%v", start, end)
 	for _, n := range s.nodes[start+1 : end] {
 		//
@@ -420,7 +1575,7 @@ func (s *Stage) parseIfKeyword(start, end int) error {
 			// needs condition
 			cond, ok := n.Node()
 			if !ok {
-				return &ErrInvalidToken{}
+				return unexpectedNode(n, "expected a condition")
 			}
 
 			n1.Condition = cond
@@ -449,20 +1604,19 @@ func (s *Stage) parseIfKeyword(start, end int) error {
 				}
 
 				// done
-				s.replaceRange(result, start, end-1)
-				return nil
+				return s.replaceRange(result, start, end-1)
 			default:
-				panic("unreachable")
+				return unexpectedNode(n, "unexpected in if")
 			}
 		} else if node, ok := n.Node(); ok {
 			// append to body
 			body = append(body, node)
 		} else {
-			panic("unreachable")
+			return unexpectedNode(n, "unexpected in if")
 		}
 	}
 
-	panic("unreachable")
+	return unexpectedNode(s.nodes[start], "missing end")
 }
 
 // parseRange is main parsing method of this parser
@@ -474,6 +1628,24 @@ func (s *Stage) parseRange(start, end int) error {
 
 	s.Println("parseUnbracketed:", "op:", op, "at", pivot)
 	switch {
+	case isMemberOperator(op.Value):
+		// ... before op name ...
+		return s.parseMember(start, pivot)
+	case op.Value == lambdaOperator:
+		// ... params => body ...
+		return s.parseLambda(pivot)
+	case op.Value == resultOperator:
+		// ... name(param, ...) -> type ...
+		return s.parseResultAnnotation(pivot)
+	case op.Value == ":" && !s.hasOperator(start, end, "?"):
+		// ... name : type ...
+		return s.parseAnnotation(pivot)
+	case isConditionalOperator(op.Value):
+		// ... cond ? then : else ...
+		return s.parseConditional(start, end)
+	case op.Value == "=" && s.isMultipleAssign(start, end, pivot):
+		// ... a, b = c, d ...
+		return s.parseMultipleAssign(start, end, pivot)
 	case isPrefixUnaryOperator(op.Value):
 		// ... op after ...
 		after, err := s.getNodeAfter(pivot)
@@ -490,11 +1662,11 @@ func (s *Stage) parseRange(start, end int) error {
 					Left:  before,
 					Op:    op.Value,
 					Right: after,
+					Pos:   op.Pos,
 				}
 
 				s.Printf("parseUnbracketed: pivot:%v [%s %s %s] → %s", pivot, before, op, after, n)
-				s.replaceRange(n, pivot-1, pivot+1)
-				return nil
+				return s.replaceRange(n, pivot-1, pivot+1)
 			}
 
 			// nope, continue as prefixed unary
@@ -503,11 +1675,14 @@ func (s *Stage) parseRange(start, end int) error {
 		n := &UnaryExpression{
 			Op:   op.Value,
 			Expr: after,
+			Pos:  op.Pos,
 		}
 
 		s.Printf("parseUnbracketed: [%s %s] → %s", op, after, n)
-		s.replaceRange(n, pivot, pivot+1)
-		return nil
+		return s.replaceRange(n, pivot, pivot+1)
+	case isSuffixUnaryOperator(op.Value) && s.isMemberOperatorAt(pivot-2):
+		// field access binds tighter than ++ and --
+		return s.parseMember(start, pivot-2)
 	case isSuffixUnaryOperator(op.Value):
 		// ... before op ...
 		before, err := s.getNodeBefore(pivot)
@@ -516,14 +1691,18 @@ func (s *Stage) parseRange(start, end int) error {
 			return err
 		}
 
+		if err := s.checkAssignable(op, before); err != nil {
+			return err
+		}
+
 		n := &UnaryExpression{
 			Expr: before,
 			Op:   op.Value,
+			Pos:  op.Pos,
 		}
 
 		s.Printf("parseUnbracketed: [%s %s] → %s", before, op, n)
-		s.replaceRange(n, pivot-1, pivot)
-		return nil
+		return s.replaceRange(n, pivot-1, pivot)
 	case isBinaryOperator(op.Value):
 		// ... before op after ...
 		before, err := s.getNodeBefore(pivot)
@@ -536,39 +1715,53 @@ func (s *Stage) parseRange(start, end int) error {
 			return err
 		}
 
+		if op.Value == "=" {
+			if err := s.checkAssignable(op, before); err != nil {
+				return err
+			}
+		}
+
 		n := &BinaryExpression{
 			Left:  before,
 			Op:    op.Value,
 			Right: after,
+			Pos:   op.Pos,
 		}
 
 		s.Printf("parseUnbracketed: pivot:%v [%s %s %s] → %s", pivot, before, op, after, n)
-		s.replaceRange(n, pivot-1, pivot+1)
-		return nil
+		return s.replaceRange(n, pivot-1, pivot+1)
 
 	default:
 		return fmt.Errorf("unsupported operator: %s", op.Value)
 	}
 }
 
-// findBrackets is responsible for finding pair of brackets
+// findBrackets is responsible for finding pair of brackets,
+// parenthesis, square brackets or braces
 func (s *Stage) findBrackets() (int, int, bool, error) {
 	lastOpen := -1
 
 	for i, node := range s.nodes {
 		if token, ok := node.Token(); ok {
 			switch token.Type {
-			case LeftParen:
+			case LeftParen, LeftBracket, LeftBrace:
 				lastOpen = i
-			case RightParen:
+			case RightParen, RightBracket, RightBrace:
 				switch {
 				case lastOpen < 0:
 					// never opened
 					err := &ErrInvalidToken{
 						Token:  token,
-						Reason: "unmatched closing parenthesis",
+						Reason: "unmatched closing " + bracketName(token),
 					}
 					return 0, i, false, err
+				case !isMatchingBracket(s.nodes[lastOpen].token, token):
+					// closed by the wrong kind
+					err := &ErrInvalidToken{
+						Token:  token,
+						Reason: "mismatched closing " + bracketName(token),
+					}
+					return lastOpen, i, false, err
 				default:
 					// matched
 					return lastOpen, i, true, nil
@@ -587,6 +1780,117 @@ func (s *Stage) findBrackets() (int, int, bool, error) {
 	}
 }
 
+// isMatchingBracket checks if the closing token matches
+// the opening one
+func isMatchingBracket(open, close *Token) bool {
+	switch open.Type {
+	case LeftParen:
+		return close.Type == RightParen
+	case LeftBracket:
+		return close.Type == RightBracket
+	case LeftBrace:
+		return close.Type == RightBrace
+	default:
+		return false
+	}
+}
+
+// bracketName names a bracket token for errors
+func bracketName(token *Token) string {
+	switch token.Type {
+	case LeftBracket, RightBracket:
+		return "bracket"
+	case LeftBrace, RightBrace:
+		return "brace"
+	default:
+		return "parenthesis"
+	}
+}
+
+// parseSquareBracketed parses expr[index], expr[start:end],
+// their optional expr?[...] forms and list literals
+func (s *Stage) parseSquareBracketed(start, end int) error {
+	open, _ := s.nodes[start].Token()
+
+	s.PrintDetails("parseSquareBracketed %v..%v", start, end)
+
+	// the operand being indexed, before the `?` if optional
+	optional := s.isOptionalBracket(start)
+	exprAt := start - 1
+	if optional {
+		exprAt--
+	}
+
+	var expr Node
+	if exprAt >= 0 {
+		expr, _ = s.nodes[exprAt].Node()
+	}
+	switch {
+	case expr == nil && optional:
+		q, _ := s.nodes[start-1].Token()
+		return &ErrInvalidToken{
+			Token:  q,
+			Reason: "unexpected '?'",
+		}
+	case expr == nil:
+		return s.parseList(start, end)
+	case s.isMemberOperatorAt(exprAt - 1):
+		// field access binds tighter than indexing
+		return s.parseMember(0, exprAt-1)
+	}
+
+	// index, or slice bounds separated by a ':' that
+	// doesn't belong to a `c ? a : b`
+	colon := -1
+	pending := 0
+	for i := start + 1; i < end; i++ {
+		t, ok := s.nodes[i].Token()
+		switch {
+		case !ok:
+			// node
+		case t.Is(Operator, "?"):
+			pending++
+		case t.Is(Operator, ":") && pending > 0:
+			pending--
+		case t.Is(Operator, ":"):
+			if colon >= 0 {
+				return &ErrInvalidToken{
+					Token:  t,
+					Reason: "unexpected ':'",
+				}
+			}
+			colon = i
+		}
+	}
+
+	segments := [][2]int{{start + 1, end}}
+	if colon >= 0 {
+		segments = [][2]int{{start + 1, colon}, {colon + 1, end}}
+	}
+
+	parts, done, err := s.parseSegments(open, segments)
+	if !done || err != nil {
+		return err
+	}
+
+	var result Node
+	switch {
+	case colon >= 0:
+		result = &SliceExpression{Expr: expr, Start: parts[0], End: parts[1], Optional: optional, Pos: open.Pos}
+	case parts[0] == nil:
+		// empty brackets
+		close, _ := s.nodes[end].Token()
+		return &ErrInvalidToken{
+			Token:  close,
+			Reason: "unexpected ']'",
+		}
+	default:
+		result = &IndexExpression{Expr: expr, Index: parts[0], Optional: optional, Pos: open.Pos}
+	}
+
+	return s.replaceRange(result, exprAt, end)
+}
+
 // ParseLeaf logs leaves
 func (s *Stage) ParseLeaf(token *Token) (Node, error) {
 	leaf, err := parseLeaf(token)
@@ -606,15 +1910,27 @@ func parseLeaf(token *Token) (Node, error) {
 
 	switch token.Type {
 	case Identifier:
-		leaf = NewVariable(token.Value)
+		leaf = &Variable{Name: token.Value, Pos: token.Pos}
 	case Integer:
-		leaf, _ = NewIntegerString(token.Value)
+		leaf = parseIntegerLeaf(token.Value)
 	case Float:
-		leaf, _ = NewFloatString(token.Value)
+		if f, err := NewFloatString(token.Value); err == nil {
+			leaf = f
+		}
+	case Decimal:
+		if d, err := NewDecimalString(token.Value); err == nil {
+			leaf = d
+		}
 	case String:
 		leaf = NewString(token.Value)
 	case Boolean:
 		leaf, _ = NewBooleanString(token.Value)
+	case Null:
+		leaf = NewNull()
+	case Keyword:
+		if token.Value == "break" {
+			leaf = &BreakNode{}
+		}
 	}
 
 	switch {
@@ -625,12 +1941,26 @@ func parseLeaf(token *Token) (Node, error) {
 	}
 }
 
+// parseIntegerLeaf parses integer literals, using a big integer
+// if it doesn't fit in an int
+func parseIntegerLeaf(s string) Node {
+	if n, err := NewIntegerString(s); err == nil {
+		return n
+	}
+	if n, err := NewBigIntegerString(s); err == nil {
+		return n
+	}
+	return nil
+}
+
 // isLeafToken checks wheter totken is an leaf
 func isLeafToken(token *Token) bool {
 	if token != nil {
 		switch token.Type {
-		case Identifier, Integer, Float, String, Boolean:
+		case Identifier, Integer, Float, Decimal, String, Boolean, Null:
 			return true
+		case Keyword:
+			return token.Value == "break"
 		}
 	}
 	return false
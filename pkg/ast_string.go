@@ -3,14 +3,24 @@ package javalanche
 import (
 	"fmt"
 	"strconv"
+	"strings"
 )
 
 var (
-	_ Value          = (*StringLiteral)(nil)
-	_ Node           = (*StringLiteral)(nil)
-	_ fmt.GoStringer = (*StringLiteral)(nil)
-	_ fmt.Stringer   = (*StringLiteral)(nil)
-	_ AddValuer      = (*StringLiteral)(nil)
+	_ Value              = (*StringLiteral)(nil)
+	_ Node               = (*StringLiteral)(nil)
+	_ fmt.GoStringer     = (*StringLiteral)(nil)
+	_ fmt.Stringer       = (*StringLiteral)(nil)
+	_ AddValuer          = (*StringLiteral)(nil)
+	_ MulValuer          = (*StringLiteral)(nil)
+	_ GreaterValuer      = (*StringLiteral)(nil)
+	_ GreaterEqualValuer = (*StringLiteral)(nil)
+	_ LesserValuer       = (*StringLiteral)(nil)
+	_ LesserEqualValuer  = (*StringLiteral)(nil)
+	_ ContainsValuer     = (*StringLiteral)(nil)
+	_ IndexValuer        = (*StringLiteral)(nil)
+	_ SliceValuer        = (*StringLiteral)(nil)
+	_ IterValuer         = (*StringLiteral)(nil)
 )
 
 type StringLiteral struct {
@@ -50,8 +60,13 @@ func (n *StringLiteral) Eval(ctx *Javalanche) (Value, error) {
 	return n, nil
 }
 
+// Equal compares against other strings, see looseEqual
+// for how == compares strings and numbers
 func (n *StringLiteral) Equal(v Value) bool {
-	return n.Value == v.AsString()
+	if m, ok := v.(*StringLiteral); ok {
+		return n.Value == m.Value
+	}
+	return false
 }
 
 // AddValue concatenates two strings, converting the argument
@@ -61,3 +76,111 @@ func (n *StringLiteral) AddValue(v Value) (Value, error) {
 	result := n.Value + rightStr
 	return NewString(result), nil
 }
+
+// maxRepeatBytes limits the size of repeated strings, as
+// bigger ones could take all the memory
+const maxRepeatBytes = 1 << 26
+
+// MulValue repeats the string an integer number of times,
+// up to maxRepeatBytes
+func (n *StringLiteral) MulValue(v Value) (Value, error) {
+	switch right := v.(type) {
+	case *IntegerLiteral:
+		count := right.Value
+		switch {
+		case count < 0:
+			return nil, errNegRepeat
+		case len(n.Value) > 0 && count > maxRepeatBytes/len(n.Value):
+			return nil, fmt.Errorf("repeating %v times: %w", count, errTooLarge)
+		}
+		return NewString(strings.Repeat(n.Value, count)), nil
+	case *BigIntegerLiteral:
+		if right.Value.Sign() < 0 {
+			return nil, errNegRepeat
+		}
+		return nil, fmt.Errorf("repeating %v times: %w", right.Value, errTooLarge)
+	default:
+		return nil, errInvalidTypes
+	}
+}
+
+// compare compares lexicographically against another string
+func (n *StringLiteral) compare(v Value) (int, error) {
+	if right, ok := v.(*StringLiteral); ok {
+		return strings.Compare(n.Value, right.Value), nil
+	}
+	return 0, errInvalidTypes
+}
+
+func (n *StringLiteral) LesserValue(v Value) (Value, error) {
+	c, err := n.compare(v)
+	if err != nil {
+		return nil, err
+	}
+	return NewBoolean(c < 0), nil
+}
+
+func (n *StringLiteral) GreaterValue(v Value) (Value, error) {
+	c, err := n.compare(v)
+	if err != nil {
+		return nil, err
+	}
+	return NewBoolean(c > 0), nil
+}
+
+func (n *StringLiteral) LesserEqualValue(v Value) (Value, error) {
+	c, err := n.compare(v)
+	if err != nil {
+		return nil, err
+	}
+	return NewBoolean(c <= 0), nil
+}
+
+func (n *StringLiteral) GreaterEqualValue(v Value) (Value, error) {
+	c, err := n.compare(v)
+	if err != nil {
+		return nil, err
+	}
+	return NewBoolean(c >= 0), nil
+}
+
+// ContainsValue tells if the given string is a substring
+func (n *StringLiteral) ContainsValue(v Value) (Value, error) {
+	if sub, ok := v.(*StringLiteral); ok {
+		return NewBoolean(strings.Contains(n.Value, sub.Value)), nil
+	}
+	return nil, errInvalidTypes
+}
+
+// IndexValue returns the rune at the given position
+func (n *StringLiteral) IndexValue(v Value) (Value, error) {
+	runes := []rune(n.Value)
+	i, err := resolveIndex(v, len(runes))
+	if err != nil {
+		return nil, err
+	}
+	return NewString(string(runes[i])), nil
+}
+
+// SliceValue returns the runes between the given positions
+func (n *StringLiteral) SliceValue(start, end Value) (Value, error) {
+	runes := []rune(n.Value)
+	from, until, err := resolveSlice(start, end, len(runes))
+	if err != nil {
+		return nil, err
+	}
+	return NewString(string(runes[from:until])), nil
+}
+
+// IterValue gives the runes in order, as strings
+func (n *StringLiteral) IterValue() Iterator {
+	runes := []rune(n.Value)
+	i := 0
+	return IteratorFunc(func() (Value, bool, error) {
+		if i >= len(runes) {
+			return nil, false, nil
+		}
+		i++
+		return NewString(string(runes[i-1])), true, nil
+	})
+}
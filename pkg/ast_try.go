@@ -0,0 +1,241 @@
+package javalanche
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	_ Value          = (*ErrorValue)(nil)
+	_ FieldValuer    = (*ErrorValue)(nil)
+	_ error          = (*ErrorValue)(nil)
+	_ fmt.GoStringer = (*ErrorValue)(nil)
+	_ fmt.Stringer   = (*ErrorValue)(nil)
+
+	_ Node           = (*TryNode)(nil)
+	_ fmt.GoStringer = (*TryNode)(nil)
+	_ fmt.Stringer   = (*TryNode)(nil)
+
+	_ Node           = (*ThrowNode)(nil)
+	_ fmt.GoStringer = (*ThrowNode)(nil)
+	_ fmt.Stringer   = (*ThrowNode)(nil)
+)
+
+// errorKindDefault is the kind of errors thrown without one
+const errorKindDefault = "Error"
+
+// ErrorValue is an error a script caught or is going to throw.
+// It's also the error carrying it while thrown
+type ErrorValue struct {
+	Kind    string
+	Message string
+	Pos     Position
+
+	// err is the error of the interpreter it describes, if any
+	err error
+}
+
+// NewError creates an error value of the given kind
+func NewError(kind, message string) *ErrorValue {
+	return &ErrorValue{Kind: kind, Message: message}
+}
+
+// newErrorValue returns the error value thrown, or one
+// describing an error of the interpreter
+func newErrorValue(err error) *ErrorValue {
+	var e *ErrorValue
+	if errors.As(err, &e) {
+		return e
+	}
+
+	e = NewError(errorKind(err), err.Error())
+	e.err = err
+
+	var re *RuntimeError
+	if errors.As(err, &re) {
+		e.Pos = re.Pos
+	}
+	return e
+}
+
+// errorKinds name the kinds of the errors of the interpreter
+// by their code. Others are errorKindDefault
+var errorKinds = map[ErrorCode]string{
+	CodeType:         "TypeError",
+	CodeName:         "NameError",
+	CodeZeroDivision: "ZeroDivisionError",
+	CodeIndex:        "IndexError",
+	CodeLimit:        "LimitError",
+}
+
+// errorKind names the kind of an error of the interpreter
+func errorKind(err error) string {
+	var e Error
+	if errors.As(classify(err, Position{}), &e) {
+		if kind, ok := errorKinds[e.Code()]; ok {
+			return kind
+		}
+	}
+	return errorKindDefault
+}
+
+// isCatchable tells if catch can handle an error. break and
+// closing generators aren't errors of the script, and
+// interrupted scripts need to stop
+func isCatchable(err error) bool {
+	return err != errBreak && err != errClosed && !errors.Is(err, errInterrupted)
+}
+
+func (e *ErrorValue) GoString() string {
+	return fmt.Sprintf("&ErrorValue{%q, %q, %v}", e.Kind, e.Message, e.Pos)
+}
+
+func (e *ErrorValue) String() string {
+	return e.Kind + ": " + e.Message
+}
+
+func (e *ErrorValue) Error() string {
+	return e.String()
+}
+
+func (e *ErrorValue) Code() ErrorCode {
+	return CodeThrown
+}
+
+func (e *ErrorValue) Position() Position {
+	return e.Pos
+}
+
+func (e *ErrorValue) Type() ValueType {
+	return ValueTypeError
+}
+
+func (e *ErrorValue) AsFloat64() float64 {
+	return 0
+}
+
+func (e *ErrorValue) AsString() string {
+	return e.String()
+}
+
+func (e *ErrorValue) AsBool() bool {
+	return true
+}
+
+func (e *ErrorValue) Equal(v Value) bool {
+	return e == v
+}
+
+// FieldValue returns the message, kind, line and column.
+// line and column are null when the position isn't known
+func (e *ErrorValue) FieldValue(name string) (Value, error) {
+	switch name {
+	case "message":
+		return NewString(e.Message), nil
+	case "kind":
+		return NewString(e.Kind), nil
+	case "line", "column":
+		switch {
+		case !e.Pos.IsValid():
+			return NewNull(), nil
+		case name == "line":
+			return NewInteger(e.Pos.Line), nil
+		default:
+			return NewInteger(e.Pos.Column), nil
+		}
+	default:
+		return nil, fmt.Errorf("error has no field %q", name)
+	}
+}
+
+// TryNode represents try body catch name body finally body end.
+// Without catch Name is empty
+type TryNode struct {
+	Body    BodyNode
+	Name    string
+	Catch   BodyNode
+	Finally BodyNode
+	Pos     Position
+}
+
+func (n *TryNode) GoString() string {
+	return fmt.Sprintf("&TryNode{%#v, %q, %#v, %#v}", n.Body, n.Name, n.Catch, n.Finally)
+}
+
+func (n *TryNode) String() string {
+	return "try ... end"
+}
+
+// Eval evaluates the body, and the catch body with the error
+// if it fails. The finally body is always evaluated last
+func (n *TryNode) Eval(ctx *Javalanche) (Value, error) {
+	val, err := n.eval(ctx)
+	return val, traced(err, "try", n.Pos)
+}
+
+func (n *TryNode) eval(ctx *Javalanche) (Value, error) {
+	val, err := n.Body.Eval(ctx)
+	if err != nil && n.Name != "" && isCatchable(err) {
+		val, err = n.catch(ctx, err)
+	}
+
+	if n.Finally != nil {
+		if _, ferr := n.Finally.Eval(ctx); ferr != nil {
+			return nil, ferr
+		}
+	}
+
+	if err != nil {
+		return nil, err
+	}
+	return val, nil
+}
+
+// catch evaluates the catch body in a block scope holding
+// the error, so the name is only set inside it
+func (n *TryNode) catch(ctx *Javalanche, caught error) (Value, error) {
+	saved := ctx.scope
+	ctx.scope = &scope{
+		vars:   map[string]Value{n.Name: newErrorValue(caught)},
+		parent: saved,
+		block:  true,
+	}
+	defer func() { ctx.scope = saved }()
+
+	return n.Catch.Eval(ctx)
+}
+
+// ThrowNode represents throw value. Error values are thrown
+// as they are, other values become the message of an Error
+type ThrowNode struct {
+	Value Node
+	Pos   Position
+}
+
+func (n *ThrowNode) GoString() string {
+	return fmt.Sprintf("&ThrowNode{%#v}", n.Value)
+}
+
+func (n *ThrowNode) String() string {
+	return fmt.Sprintf("throw %s", n.Value)
+}
+
+func (n *ThrowNode) Eval(ctx *Javalanche) (Value, error) {
+	v, err := n.Value.Eval(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	e, ok := v.(*ErrorValue)
+	if ok && e.err != nil {
+		// rethrown as it was
+		return nil, e.err
+	}
+	if !ok {
+		e = NewError(errorKindDefault, v.AsString())
+	}
+	if !e.Pos.IsValid() {
+		e.Pos = n.Pos
+	}
+	return nil, atPosition(e, e.Pos)
+}
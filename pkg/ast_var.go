@@ -11,6 +11,7 @@ var (
 
 type Variable struct {
 	Name string
+	Pos  Position
 }
 
 type SetValuer interface {
@@ -21,9 +22,16 @@ func NewVariable(n string) *Variable {
 	return &Variable{Name: n}
 }
 
-// evaluates the variable node by getting its value from the evaluator
+// evaluates the variable node by getting its value from the evaluator,
+// or the builtin function with that name
 func (v *Variable) Eval(ctx *Javalanche) (Value, error) {
-	return ctx.GetValue(v.Name)
+	val, err := ctx.GetValue(v.Name)
+	if err != nil {
+		if fn, ok := builtins[v.Name]; ok {
+			return fn, nil
+		}
+	}
+	return val, atPosition(err, v.Pos)
 }
 
 // sets the value of the variable in the evaluator.
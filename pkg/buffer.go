@@ -0,0 +1,76 @@
+package javalanche
+
+import (
+	"bytes"
+	"io"
+	"sync"
+)
+
+// Implemented Interfaces
+var (
+	_ io.Reader = (*lineBuffer)(nil)
+	_ io.Writer = (*lineBuffer)(nil)
+)
+
+// lineBuffer is a goroutine safe buffer between EvalLine and
+// the Tokenizer. Reads block until data is available instead
+// of reporting io.EOF, so the Tokenizer waits for the next line
+// without spinning
+type lineBuffer struct {
+	mu     sync.Mutex
+	cond   *sync.Cond
+	buf    bytes.Buffer
+	closed bool
+}
+
+func newLineBuffer() *lineBuffer {
+	b := &lineBuffer{}
+	b.cond = sync.NewCond(&b.mu)
+	return b
+}
+
+// Read blocks until there is data to read or the buffer is closed
+func (b *lineBuffer) Read(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for b.buf.Len() == 0 && !b.closed {
+		b.cond.Wait()
+	}
+
+	if b.buf.Len() == 0 {
+		return 0, io.EOF
+	}
+
+	return b.buf.Read(p)
+}
+
+// Write appends data and wakes up any pending reader
+func (b *lineBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		return 0, io.ErrClosedPipe
+	}
+
+	n, err := b.buf.Write(p)
+	b.cond.Broadcast()
+	return n, err
+}
+
+// WriteString appends a string and wakes up any pending reader
+func (b *lineBuffer) WriteString(s string) (int, error) {
+	return b.Write([]byte(s))
+}
+
+// Close makes pending and future reads return io.EOF once
+// the remaining data is consumed
+func (b *lineBuffer) Close() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.closed = true
+	b.cond.Broadcast()
+	return nil
+}
@@ -0,0 +1,195 @@
+package javalanche
+
+import (
+	"fmt"
+	"strings"
+)
+
+var (
+	_ Value          = (*BuiltinFunction)(nil)
+	_ Node           = (*BuiltinFunction)(nil)
+	_ CallValuer     = (*BuiltinFunction)(nil)
+	_ fmt.GoStringer = (*BuiltinFunction)(nil)
+	_ fmt.Stringer   = (*BuiltinFunction)(nil)
+)
+
+// builtins are the functions available to every script,
+// unless a variable with the same name hides them
+var builtins = newBuiltins(stringBuiltins, collectionBuiltins, errorBuiltins)
+
+// newBuiltins indexes lists of builtin functions by name
+func newBuiltins(lists ...[]*BuiltinFunction) map[string]*BuiltinFunction {
+	m := make(map[string]*BuiltinFunction)
+	for _, list := range lists {
+		for _, fn := range list {
+			m[fn.Name] = fn
+		}
+	}
+	return m
+}
+
+// BuiltinHandler implements a builtin function
+type BuiltinHandler func(ctx *Javalanche, args BuiltinArgs) (Value, error)
+
+// BuiltinFunction is a function implemented in Go
+type BuiltinFunction struct {
+	Name string
+	// Params names the parameters for error messages.
+	// Optional ones end with `?` and come last
+	Params  []string
+	Handler BuiltinHandler
+}
+
+func (fn *BuiltinFunction) GoString() string {
+	return fmt.Sprintf("&BuiltinFunction{%q}", fn.Name)
+}
+
+func (fn *BuiltinFunction) String() string {
+	return fmt.Sprintf("%s(%s)", fn.Name, strings.Join(fn.Params, ", "))
+}
+
+func (fn *BuiltinFunction) Type() ValueType {
+	return ValueTypeFunction
+}
+
+func (fn *BuiltinFunction) AsFloat64() float64 {
+	return 0
+}
+
+func (fn *BuiltinFunction) AsString() string {
+	return fn.String()
+}
+
+func (fn *BuiltinFunction) AsBool() bool {
+	return true
+}
+
+func (fn *BuiltinFunction) Eval(ctx *Javalanche) (Value, error) {
+	return fn, nil
+}
+
+func (fn *BuiltinFunction) Equal(v Value) bool {
+	return fn == v
+}
+
+// CallValue checks the number of arguments and calls the handler
+func (fn *BuiltinFunction) CallValue(ctx *Javalanche, args ...Value) (Value, error) {
+	required := 0
+	for _, p := range fn.Params {
+		if !strings.HasSuffix(p, "?") {
+			required++
+		}
+	}
+
+	if len(args) < required || len(args) > len(fn.Params) {
+		return nil, argumentsError(fn.Name, required, len(fn.Params), len(args))
+	}
+	return fn.Handler(ctx, BuiltinArgs{fn, args, ctx})
+}
+
+// argumentsError reports a call with the wrong number of arguments
+func argumentsError(name string, min, max, got int) error {
+	want := fmt.Sprintf("%v arguments", min)
+	switch {
+	case min != max:
+		want = fmt.Sprintf("%v to %v arguments", min, max)
+	case min == 1:
+		want = "1 argument"
+	}
+	return typeErrorf("%s: expects %s, got %v", name, want, got)
+}
+
+// BuiltinArgs gives builtins typed access to their arguments,
+// with errors naming the function and the parameter
+type BuiltinArgs struct {
+	fn   *BuiltinFunction
+	args []Value
+	ctx  *Javalanche
+}
+
+// Len returns the number of arguments given
+func (a BuiltinArgs) Len() int {
+	return len(a.args)
+}
+
+// Has tells if an optional argument was given
+func (a BuiltinArgs) Has(i int) bool {
+	return i < len(a.args)
+}
+
+// Value returns an argument of any type
+func (a BuiltinArgs) Value(i int) Value {
+	return a.args[i]
+}
+
+// Errorf returns an error about the given parameter
+func (a BuiltinArgs) Errorf(i int, format string, args ...any) error {
+	name := strings.TrimSuffix(a.fn.Params[i], "?")
+	msg := fmt.Sprintf(format, args...)
+	return fmt.Errorf("%s: parameter %q %s", a.fn.Name, name, msg)
+}
+
+// typeError reports an argument of the wrong type
+func (a BuiltinArgs) typeError(i int, want string) error {
+	return &TypeError{Err: a.Errorf(i, "must be %s, got %s", want, a.args[i].Type())}
+}
+
+// String returns a string argument
+func (a BuiltinArgs) String(i int) (string, error) {
+	if s, ok := a.args[i].(*StringLiteral); ok {
+		return s.Value, nil
+	}
+	return "", a.typeError(i, "a string")
+}
+
+// Int returns an integer argument that fits in an int
+func (a BuiltinArgs) Int(i int) (int, error) {
+	if n, ok := a.args[i].(*IntegerLiteral); ok {
+		return n.Value, nil
+	}
+	return 0, a.typeError(i, "an int")
+}
+
+// Function returns an argument that can be called
+func (a BuiltinArgs) Function(i int) (CallValuer, error) {
+	if fn, ok := a.args[i].(CallValuer); ok {
+		return fn, nil
+	}
+	return nil, a.typeError(i, "a function")
+}
+
+// Sequence returns the items of a list or a range, or the
+// runes of a string as strings, and whether it was a string
+func (a BuiltinArgs) Sequence(i int) ([]Value, bool, error) {
+	switch v := a.args[i].(type) {
+	case *ListLiteral:
+		return v.Items, false, nil
+	case IterValuer:
+		_, isString := v.(*StringLiteral)
+		items, err := iterValues(a.ctx, v)
+		return items, isString, err
+	default:
+		return nil, false, a.typeError(i, "a string or a list")
+	}
+}
+
+// Iter returns an Iterator over the same items as Sequence,
+// reading them one at a time, and whether it was a string.
+// It needs to be closed
+func (a BuiltinArgs) Iter(i int) (Iterator, bool, error) {
+	v, ok := a.args[i].(IterValuer)
+	if !ok {
+		return nil, false, a.typeError(i, "a string or a list")
+	}
+
+	_, isString := v.(*StringLiteral)
+	return v.IterValue(), isString, nil
+}
+
+// List returns a list argument
+func (a BuiltinArgs) List(i int) (*ListLiteral, error) {
+	if l, ok := a.args[i].(*ListLiteral); ok {
+		return l, nil
+	}
+	return nil, a.typeError(i, "a list")
+}
@@ -0,0 +1,428 @@
+package javalanche
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// collectionBuiltins work on lists, and on strings as lists of
+// runes. The ones picking items give a string back for strings
+var collectionBuiltins = []*BuiltinFunction{
+	{Name: "map", Params: []string{"items", "fn"}, Handler: builtinMap},
+	{Name: "filter", Params: []string{"items", "fn"}, Handler: builtinFilter},
+	{Name: "reduce", Params: []string{"items", "fn", "initial?"}, Handler: builtinReduce},
+	{Name: "sort", Params: []string{"items", "compare?"}, Handler: builtinSort},
+	{Name: "reverse", Params: []string{"items"}, Handler: builtinReverse},
+	{Name: "zip", Params: []string{"a", "b"}, Handler: builtinZip},
+	{Name: "enumerate", Params: []string{"items", "start?"}, Handler: builtinEnumerate},
+	{Name: "range", Params: []string{"start", "stop?", "step?"}, Handler: builtinRange},
+	{Name: "sum", Params: []string{"items", "initial?"}, Handler: builtinSum},
+	{Name: "any", Params: []string{"items", "fn?"}, Handler: builtinAny},
+	{Name: "all", Params: []string{"items", "fn?"}, Handler: builtinAll},
+	{Name: "unique", Params: []string{"items"}, Handler: builtinUnique},
+}
+
+// newSequence returns the items as a list, or joined as a
+// string if they were taken from one
+func newSequence(items []Value, isString bool) Value {
+	if !isString {
+		return NewList(items...)
+	}
+
+	var sb strings.Builder
+	for _, item := range items {
+		sb.WriteString(item.AsString())
+	}
+	return NewString(sb.String())
+}
+
+// builtinMap calls fn on each item, and returns a list
+// of the results
+func builtinMap(ctx *Javalanche, args BuiltinArgs) (Value, error) {
+	fn, err := args.Function(1)
+	if err != nil {
+		return nil, err
+	}
+	next, _, err := args.Iter(0)
+	if err != nil {
+		return nil, err
+	}
+
+	var out []Value
+	err = forEach(ctx, next, func(item Value) (bool, error) {
+		v, err := fn.CallValue(ctx, item)
+		if err != nil {
+			return false, err
+		}
+		out = append(out, v)
+		return true, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return NewList(out...), nil
+}
+
+// builtinFilter keeps the items fn returns true for
+func builtinFilter(ctx *Javalanche, args BuiltinArgs) (Value, error) {
+	fn, err := args.Function(1)
+	if err != nil {
+		return nil, err
+	}
+	next, isString, err := args.Iter(0)
+	if err != nil {
+		return nil, err
+	}
+
+	var out []Value
+	err = forEach(ctx, next, func(item Value) (bool, error) {
+		keep, err := fn.CallValue(ctx, item)
+		if err == nil && keep.AsBool() {
+			out = append(out, item)
+		}
+		return true, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return newSequence(out, isString), nil
+}
+
+// builtinReduce combines the items from left to right with
+// fn(acc, item), starting from initial or the first item
+func builtinReduce(ctx *Javalanche, args BuiltinArgs) (Value, error) {
+	fn, err := args.Function(1)
+	if err != nil {
+		return nil, err
+	}
+	next, _, err := args.Iter(0)
+	if err != nil {
+		return nil, err
+	}
+
+	var acc Value
+	if args.Has(2) {
+		acc = args.Value(2)
+	}
+
+	err = forEach(ctx, next, func(item Value) (bool, error) {
+		var err error
+		if acc == nil {
+			acc = item
+		} else {
+			acc, err = fn.CallValue(ctx, acc, item)
+		}
+		return true, err
+	})
+	switch {
+	case err != nil:
+		return nil, err
+	case acc == nil:
+		return nil, args.Errorf(0, "can't be empty without an initial value")
+	default:
+		return acc, nil
+	}
+}
+
+// builtinSort returns the items in ascending order, keeping
+// the order of equal ones. compare(a, b) returns true, or a
+// negative number, when a goes before b
+func builtinSort(ctx *Javalanche, args BuiltinArgs) (Value, error) {
+	if r, ok := args.Value(0).(*RangeLiteral); ok && !args.Has(1) {
+		// already in order, one way or the other
+		if r.Step < 0 {
+			if rev, ok := r.reversed(); ok {
+				return rev, nil
+			}
+		} else {
+			return r, nil
+		}
+	}
+
+	items, isString, err := args.Sequence(0)
+	if err != nil {
+		return nil, err
+	}
+
+	less := func(a, b Value) (Value, error) {
+		return (&BinaryExpression{Op: "<"}).apply(ctx, a, b)
+	}
+	if args.Has(1) {
+		fn, err := args.Function(1)
+		if err != nil {
+			return nil, err
+		}
+		less = func(a, b Value) (Value, error) {
+			return fn.CallValue(ctx, a, b)
+		}
+	}
+
+	out := make([]Value, len(items))
+	copy(out, items)
+
+	sort.SliceStable(out, func(i, j int) bool {
+		if err != nil {
+			return false
+		}
+
+		var v Value
+		if v, err = less(out[i], out[j]); err != nil {
+			return false
+		}
+		if isNumber(v) {
+			return v.AsFloat64() < 0
+		}
+		return v.AsBool()
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", args.fn.Name, err)
+	}
+	return newSequence(out, isString), nil
+}
+
+// builtinReverse reverses a list, or a string rune by rune
+func builtinReverse(_ *Javalanche, args BuiltinArgs) (Value, error) {
+	if r, ok := args.Value(0).(*RangeLiteral); ok {
+		if rev, ok := r.reversed(); ok {
+			return rev, nil
+		}
+	}
+
+	items, isString, err := args.Sequence(0)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]Value, len(items))
+	for i, item := range items {
+		out[len(items)-1-i] = item
+	}
+	return newSequence(out, isString), nil
+}
+
+// builtinZip pairs the items of a and b, as long as both have them
+func builtinZip(ctx *Javalanche, args BuiltinArgs) (Value, error) {
+	b, _, err := args.Iter(1)
+	if err != nil {
+		return nil, err
+	}
+	defer b.Close()
+
+	a, _, err := args.Iter(0)
+	if err != nil {
+		return nil, err
+	}
+
+	var out []Value
+	err = forEach(ctx, a, func(item Value) (bool, error) {
+		other, ok, err := b.Next()
+		if ok {
+			out = append(out, NewList(item, other))
+		}
+		return ok, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return NewList(out...), nil
+}
+
+// builtinEnumerate pairs each item with its position,
+// counting from start or 0
+func builtinEnumerate(ctx *Javalanche, args BuiltinArgs) (Value, error) {
+	var start int
+	if args.Has(1) {
+		var err error
+		if start, err = args.Int(1); err != nil {
+			return nil, err
+		}
+	}
+	next, _, err := args.Iter(0)
+	if err != nil {
+		return nil, err
+	}
+
+	var out []Value
+	err = forEach(ctx, next, func(item Value) (bool, error) {
+		out = append(out, NewList(NewInteger(start+len(out)), item))
+		return true, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return NewList(out...), nil
+}
+
+// builtinRange returns the range of integers from start, or 0,
+// up to but not including stop, counting by step
+func builtinRange(_ *Javalanche, args BuiltinArgs) (Value, error) {
+	bounds := []int{0, 0, 1}
+	for i := 0; i < args.Len(); i++ {
+		n, err := args.Int(i)
+		if err != nil {
+			return nil, err
+		}
+		bounds[i] = n
+	}
+
+	start, stop, step := bounds[0], bounds[1], bounds[2]
+	switch {
+	case args.Len() == 1:
+		start, stop = 0, bounds[0]
+	case step == 0:
+		return nil, args.Errorf(2, "can't be zero")
+	}
+
+	return &RangeLiteral{Start: start, End: stop, Step: step}, nil
+}
+
+// builtinSum adds the items with `+`, starting from initial,
+// or from the first item. An empty list adds up to 0
+func builtinSum(ctx *Javalanche, args BuiltinArgs) (Value, error) {
+	next, _, err := args.Iter(0)
+	if err != nil {
+		return nil, err
+	}
+
+	var acc Value
+	if args.Has(1) {
+		acc = args.Value(1)
+	}
+
+	add := &BinaryExpression{Op: "+"}
+	err = forEach(ctx, next, func(item Value) (bool, error) {
+		var err error
+		if acc == nil {
+			acc = item
+		} else {
+			acc, err = add.apply(ctx, acc, item)
+		}
+		return true, err
+	})
+	switch {
+	case err != nil:
+		return nil, fmt.Errorf("%s: %w", args.fn.Name, err)
+	case acc == nil:
+		return NewInteger(0), nil
+	default:
+		return acc, nil
+	}
+}
+
+// builtinAny tells if any item is true, or makes fn return true
+func builtinAny(ctx *Javalanche, args BuiltinArgs) (Value, error) {
+	found, err := findItem(ctx, args, true)
+	if err != nil {
+		return nil, err
+	}
+	return NewBoolean(found), nil
+}
+
+// builtinAll tells if every item is true, or makes fn return true
+func builtinAll(ctx *Javalanche, args BuiltinArgs) (Value, error) {
+	found, err := findItem(ctx, args, false)
+	if err != nil {
+		return nil, err
+	}
+	return NewBoolean(!found), nil
+}
+
+// findItem tells if there is an item that is, or makes the
+// optional fn return, the wanted truth value
+func findItem(ctx *Javalanche, args BuiltinArgs, want bool) (bool, error) {
+	var fn CallValuer
+	if args.Has(1) {
+		var err error
+		if fn, err = args.Function(1); err != nil {
+			return false, err
+		}
+	}
+	next, _, err := args.Iter(0)
+	if err != nil {
+		return false, err
+	}
+
+	var found bool
+	err = forEach(ctx, next, func(item Value) (bool, error) {
+		v := item
+		if fn != nil {
+			var err error
+			if v, err = fn.CallValue(ctx, item); err != nil {
+				return false, err
+			}
+		}
+		found = v.AsBool() == want
+		return !found, nil
+	})
+	return found, err
+}
+
+// builtinUnique removes the items `===` to an earlier one
+func builtinUnique(_ *Javalanche, args BuiltinArgs) (Value, error) {
+	if r, ok := args.Value(0).(*RangeLiteral); ok {
+		// never repeats
+		return r, nil
+	}
+
+	items, isString, err := args.Sequence(0)
+	if err != nil {
+		return nil, err
+	}
+
+	var out, unkeyed []Value
+	seen := make(map[uniqueKey]bool)
+	for _, item := range items {
+		if key, ok := newUniqueKey(item); ok {
+			if !seen[key] {
+				seen[key] = true
+				out = append(out, item)
+			}
+			continue
+		}
+
+		// compared one by one
+		found := false
+		for _, prev := range unkeyed {
+			if strictEqual(prev, item) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			unkeyed = append(unkeyed, item)
+			out = append(out, item)
+		}
+	}
+	return newSequence(out, isString), nil
+}
+
+// uniqueKey identifies a value among those `===` to it
+type uniqueKey struct {
+	t ValueType
+	s string
+}
+
+// newUniqueKey returns the key of ints, floats other than NaN,
+// strings, booleans and null, or false for other values
+func newUniqueKey(v Value) (uniqueKey, bool) {
+	switch n := v.(type) {
+	case *IntegerLiteral, *BigIntegerLiteral, *StringLiteral, *BooleanLiteral, *NullLiteral:
+		return uniqueKey{v.Type(), v.AsString()}, true
+	case *FloatLiteral:
+		f := n.AsFloat64()
+		switch {
+		case math.IsNaN(f):
+			return uniqueKey{}, false
+		case f == 0:
+			// -0 === 0
+			f = 0
+		}
+		return uniqueKey{v.Type(), strconv.FormatFloat(f, 'g', -1, 64)}, true
+	default:
+		return uniqueKey{}, false
+	}
+}
@@ -0,0 +1,23 @@
+package javalanche
+
+// errorBuiltins create error values to throw
+var errorBuiltins = []*BuiltinFunction{
+	{Name: "error", Params: []string{"message", "kind?"}, Handler: builtinError},
+}
+
+// builtinError creates an error value, of kind Error unless
+// another one is given
+func builtinError(_ *Javalanche, args BuiltinArgs) (Value, error) {
+	message, err := args.String(0)
+	if err != nil {
+		return nil, err
+	}
+
+	kind := errorKindDefault
+	if args.Has(1) {
+		if kind, err = args.String(1); err != nil {
+			return nil, err
+		}
+	}
+	return NewError(kind, message), nil
+}
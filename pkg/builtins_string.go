@@ -0,0 +1,207 @@
+package javalanche
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// stringBuiltins work on strings by rune, not by byte
+var stringBuiltins = []*BuiltinFunction{
+	{Name: "len", Params: []string{"value"}, Handler: builtinLen},
+	{Name: "upper", Params: []string{"s"}, Handler: builtinUpper},
+	{Name: "lower", Params: []string{"s"}, Handler: builtinLower},
+	{Name: "trim", Params: []string{"s", "chars?"}, Handler: builtinTrim},
+	{Name: "split", Params: []string{"s", "sep?"}, Handler: builtinSplit},
+	{Name: "join", Params: []string{"list", "sep?"}, Handler: builtinJoin},
+	{Name: "replace", Params: []string{"s", "old", "new"}, Handler: builtinReplace},
+	{Name: "contains", Params: []string{"s", "sub"}, Handler: builtinContains},
+	{Name: "startsWith", Params: []string{"s", "prefix"}, Handler: builtinStartsWith},
+	{Name: "endsWith", Params: []string{"s", "suffix"}, Handler: builtinEndsWith},
+	{Name: "indexOf", Params: []string{"s", "sub"}, Handler: builtinIndexOf},
+	{Name: "repeat", Params: []string{"s", "count"}, Handler: builtinRepeat},
+}
+
+// builtinLen returns the number of runes of a string,
+// or items of a list or a range
+func builtinLen(_ *Javalanche, args BuiltinArgs) (Value, error) {
+	switch v := args.Value(0).(type) {
+	case *StringLiteral:
+		return NewInteger(utf8.RuneCountInString(v.Value)), nil
+	case *ListLiteral:
+		return NewInteger(len(v.Items)), nil
+	case *RangeLiteral:
+		length, err := v.Len()
+		if err != nil {
+			return nil, err
+		}
+		return NewInteger(length), nil
+	default:
+		return nil, args.typeError(0, "a string or a list")
+	}
+}
+
+func builtinUpper(_ *Javalanche, args BuiltinArgs) (Value, error) {
+	s, err := args.String(0)
+	if err != nil {
+		return nil, err
+	}
+	return NewString(strings.ToUpper(s)), nil
+}
+
+func builtinLower(_ *Javalanche, args BuiltinArgs) (Value, error) {
+	s, err := args.String(0)
+	if err != nil {
+		return nil, err
+	}
+	return NewString(strings.ToLower(s)), nil
+}
+
+// builtinTrim removes whitespace, or the given runes, from
+// both ends
+func builtinTrim(_ *Javalanche, args BuiltinArgs) (Value, error) {
+	s, err := args.String(0)
+	if err != nil {
+		return nil, err
+	}
+
+	if !args.Has(1) {
+		return NewString(strings.TrimSpace(s)), nil
+	}
+
+	chars, err := args.String(1)
+	if err != nil {
+		return nil, err
+	}
+	return NewString(strings.Trim(s, chars)), nil
+}
+
+// builtinSplit splits around the separator, or around
+// runs of whitespace if none is given
+func builtinSplit(_ *Javalanche, args BuiltinArgs) (Value, error) {
+	s, err := args.String(0)
+	if err != nil {
+		return nil, err
+	}
+
+	var parts []string
+	if args.Has(1) {
+		sep, err := args.String(1)
+		if err != nil {
+			return nil, err
+		}
+		parts = strings.Split(s, sep)
+	} else {
+		parts = strings.Fields(s)
+	}
+
+	items := make([]Value, len(parts))
+	for i, part := range parts {
+		items[i] = NewString(part)
+	}
+	return NewList(items...), nil
+}
+
+// builtinJoin concatenates the items of a list, converted to
+// string, with an optional separator between them
+func builtinJoin(_ *Javalanche, args BuiltinArgs) (Value, error) {
+	list, err := args.List(0)
+	if err != nil {
+		return nil, err
+	}
+
+	var sep string
+	if args.Has(1) {
+		if sep, err = args.String(1); err != nil {
+			return nil, err
+		}
+	}
+
+	parts := make([]string, len(list.Items))
+	for i, item := range list.Items {
+		parts[i] = item.AsString()
+	}
+	return NewString(strings.Join(parts, sep)), nil
+}
+
+func builtinReplace(_ *Javalanche, args BuiltinArgs) (Value, error) {
+	s, err := args.String(0)
+	if err != nil {
+		return nil, err
+	}
+	old, err := args.String(1)
+	if err != nil {
+		return nil, err
+	}
+	replacement, err := args.String(2)
+	if err != nil {
+		return nil, err
+	}
+	return NewString(strings.ReplaceAll(s, old, replacement)), nil
+}
+
+// stringPair returns the first two arguments as strings
+func stringPair(args BuiltinArgs) (string, string, error) {
+	s, err := args.String(0)
+	if err != nil {
+		return "", "", err
+	}
+	t, err := args.String(1)
+	if err != nil {
+		return "", "", err
+	}
+	return s, t, nil
+}
+
+func builtinContains(_ *Javalanche, args BuiltinArgs) (Value, error) {
+	s, sub, err := stringPair(args)
+	if err != nil {
+		return nil, err
+	}
+	return NewBoolean(strings.Contains(s, sub)), nil
+}
+
+func builtinStartsWith(_ *Javalanche, args BuiltinArgs) (Value, error) {
+	s, prefix, err := stringPair(args)
+	if err != nil {
+		return nil, err
+	}
+	return NewBoolean(strings.HasPrefix(s, prefix)), nil
+}
+
+func builtinEndsWith(_ *Javalanche, args BuiltinArgs) (Value, error) {
+	s, suffix, err := stringPair(args)
+	if err != nil {
+		return nil, err
+	}
+	return NewBoolean(strings.HasSuffix(s, suffix)), nil
+}
+
+// builtinIndexOf returns the rune position of the first
+// occurrence of sub, or -1
+func builtinIndexOf(_ *Javalanche, args BuiltinArgs) (Value, error) {
+	s, sub, err := stringPair(args)
+	if err != nil {
+		return nil, err
+	}
+
+	i := strings.Index(s, sub)
+	if i < 0 {
+		return NewInteger(-1), nil
+	}
+	return NewInteger(utf8.RuneCountInString(s[:i])), nil
+}
+
+func builtinRepeat(_ *Javalanche, args BuiltinArgs) (Value, error) {
+	s, err := args.String(0)
+	if err != nil {
+		return nil, err
+	}
+	count, err := args.Int(1)
+	if err != nil {
+		return nil, err
+	}
+	if count < 0 {
+		return nil, args.Errorf(1, "can't be negative")
+	}
+	return NewString(s).MulValue(NewInteger(count))
+}
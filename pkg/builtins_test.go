@@ -0,0 +1,139 @@
+package javalanche
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCollectionBuiltins(t *testing.T) {
+	type testCase struct {
+		exprs  []string // strings to evaluate in order
+		result Value    // expected Value for the last expression. nil if we expect an error
+	}
+
+	ints := func(values ...int) Value {
+		items := make([]Value, len(values))
+		for i, v := range values {
+			items[i] = NewInteger(v)
+		}
+		return NewList(items...)
+	}
+
+	var cases = []testCase{
+		// map
+		{exprs: []string{"map([1, 2, 3], (x) => x * 2)"}, result: ints(2, 4, 6)},
+		{exprs: []string{"map(\"ab\", upper)"}, result: NewList(NewString("A"), NewString("B"))},
+		{exprs: []string{"map([], (x) => x)"}, result: NewList()},
+		{exprs: []string{"map(5, upper)"}, result: nil},
+		{exprs: []string{"map([1], 5)"}, result: nil},
+		{exprs: []string{"map([1], (a, b) => a)"}, result: nil},
+
+		// filter
+		{exprs: []string{"filter([1, 2, 3, 4], (x) => x % 2 == 0)"}, result: ints(2, 4)},
+		{exprs: []string{"filter(\"hello\", (c) => c != \"l\")"}, result: NewString("heo")},
+		{exprs: []string{"filter(\"añb\", (c) => c != \"b\")"}, result: NewString("añ")},
+
+		// reduce
+		{exprs: []string{"reduce([1, 2, 3], (a, b) => a + b)"}, result: NewInteger(6)},
+		{exprs: []string{"reduce([1, 2, 3], (a, b) => a * b, 10)"}, result: NewInteger(60)},
+		{exprs: []string{"reduce(\"abc\", (a, c) => c + a)"}, result: NewString("cba")},
+		{exprs: []string{"reduce([], (a, b) => a + b, 7)"}, result: NewInteger(7)},
+		{exprs: []string{"reduce([], (a, b) => a + b)"}, result: nil},
+
+		// sort
+		{exprs: []string{"sort([3, 1, 2])"}, result: ints(1, 2, 3)},
+		{exprs: []string{"sort(\"banana\")"}, result: NewString("aaabnn")},
+		{exprs: []string{"sort([3, 1, 2], (a, b) => b - a)"}, result: ints(3, 2, 1)},
+		{exprs: []string{"sort([3, 1, 2], (a, b) => a > b)"}, result: ints(3, 2, 1)},
+		{exprs: []string{"sort([[2, 1], [1, 2], [2, 0]], (a, b) => a[0] < b[0])[1]"}, result: ints(2, 1)},
+		{exprs: []string{"l = [3, 1]", "sort(l)", "l"}, result: ints(3, 1)},
+		{exprs: []string{"sort([1, \"a\"])"}, result: nil},
+
+		// reverse
+		{exprs: []string{"reverse([1, 2, 3])"}, result: ints(3, 2, 1)},
+		{exprs: []string{"reverse(\"héllo\")"}, result: NewString("olléh")},
+		{exprs: []string{"reverse([])"}, result: NewList()},
+		{exprs: []string{"reverse(1..1000000000000)[0]"}, result: NewInteger(1000000000000)},
+		{exprs: []string{"reverse(1..<10 by 3)"}, result: &RangeLiteral{Start: 7, End: 1, Step: -3, Inclusive: true}},
+		{exprs: []string{"reverse(5..<5)"}, result: NewRange(0, 0, false)},
+		{exprs: []string{"sort(1000000000000..1 by -1)[0]"}, result: NewInteger(1)},
+		{exprs: []string{"sort(1..1000000000000)[-1]"}, result: NewInteger(1000000000000)},
+		{exprs: []string{"sort(3..1 by -1, (a, b) => a > b)"}, result: ints(3, 2, 1)},
+
+		// zip
+		{exprs: []string{"zip([1, 2, 3], [4, 5])"}, result: NewList(ints(1, 4), ints(2, 5))},
+		{exprs: []string{"zip(\"ab\", [1, 2])[1]"}, result: NewList(NewString("b"), NewInteger(2))},
+		{exprs: []string{"zip([1], 2)"}, result: nil},
+		{exprs: []string{"zip(1..1000000000000, \"ab\")"}, result: NewList(NewList(NewInteger(1), NewString("a")), NewList(NewInteger(2), NewString("b")))},
+
+		// enumerate
+		{exprs: []string{"enumerate([5, 6])"}, result: NewList(ints(0, 5), ints(1, 6))},
+		{exprs: []string{"enumerate(\"a\", 1)"}, result: NewList(NewList(NewInteger(1), NewString("a")))},
+
+		// range
+		{exprs: []string{"range(4)"}, result: NewRange(0, 3, true)},
+		{exprs: []string{"range(2, 5)"}, result: NewRange(2, 4, true)},
+		{exprs: []string{"map(range(10, 0, -3), (x) => x)"}, result: ints(10, 7, 4, 1)},
+		{exprs: []string{"range(5, 2)"}, result: NewRange(0, 0, false)},
+		{exprs: []string{"len(range(1000000000000))"}, result: NewInteger(1000000000000)},
+		{exprs: []string{"range(1, 2, 0)"}, result: nil},
+		{exprs: []string{"range(\"a\")"}, result: nil},
+
+		// sum
+		{exprs: []string{"sum([1, 2, 3])"}, result: NewInteger(6)},
+		{exprs: []string{"sum([])"}, result: NewInteger(0)},
+		{exprs: []string{"sum([1.5, 2])"}, result: NewFloat(3.5)},
+		{exprs: []string{"sum([1, 2], 10)"}, result: NewInteger(13)},
+		{exprs: []string{"sum(\"abc\")"}, result: NewString("abc")},
+		{exprs: []string{"sum([1, \"a\"])"}, result: nil},
+
+		// any and all
+		{exprs: []string{"any([0, false, 1])"}, result: NewBoolean(true)},
+		{exprs: []string{"any([0, false])"}, result: NewBoolean(false)},
+		{exprs: []string{"any(\"abc\", (c) => c == \"b\")"}, result: NewBoolean(true)},
+		{exprs: []string{"all([1, true, \"x\"])"}, result: NewBoolean(true)},
+		{exprs: []string{"all([1, 0])"}, result: NewBoolean(false)},
+		{exprs: []string{"all([], (x) => false)"}, result: NewBoolean(true)},
+		{exprs: []string{"all(\"aaa\", (c) => c == \"a\")"}, result: NewBoolean(true)},
+		{exprs: []string{"any(1..1000000000000, (x) => x > 5)"}, result: NewBoolean(true)},
+		{exprs: []string{"all(1..1000000000000, (x) => x < 5)"}, result: NewBoolean(false)},
+
+		// endless generators
+		{exprs: withGenerators("any(nat(), (x) => x > 5)"), result: NewBoolean(true)},
+		{exprs: withGenerators("all(nat(), (x) => x < 3)"), result: NewBoolean(false)},
+		{exprs: withGenerators("zip(\"ab\", nat())"), result: NewList(NewList(NewString("a"), NewInteger(0)), NewList(NewString("b"), NewInteger(1)))},
+		{exprs: withGenerators("zip(nat(), [5])"), result: NewList(ints(0, 5))},
+
+		// unique
+		{exprs: []string{"unique([1, 2, 1, 3, 2])"}, result: ints(1, 2, 3)},
+		{exprs: []string{"unique(\"mississippi\")"}, result: NewString("misp")},
+		{exprs: []string{"unique([1, 1.0])"}, result: NewList(NewInteger(1), NewFloat(1))},
+		{exprs: []string{"unique([1, \"1\", 1.0, 1])"}, result: NewList(NewInteger(1), NewString("1"), NewFloat(1))},
+		{exprs: []string{"unique([[1], [1], 2, 2.0, 2])"}, result: NewList(ints(1), NewInteger(2), NewFloat(2))},
+		{exprs: []string{"len(unique(1..1000000000000))"}, result: NewInteger(1000000000000)},
+
+		// combined
+		{exprs: []string{"sum(map(filter(range(10), (x) => x % 3 == 0), (x) => x * x))"}, result: NewInteger(126)},
+	}
+
+	for _, tc := range cases {
+		ctx := New()
+
+		exprs := strings.Join(tc.exprs, "\n")
+		res, err := ctx.EvalLine(tc.exprs...)
+		ctx.Close()
+
+		switch {
+		case err != nil && tc.result == nil:
+			t.Logf("PASS: %q: failed as expected: %s", exprs, err)
+		case err == nil && tc.result == nil:
+			t.Errorf("ERROR: %q: should have failed, got %q instead", exprs, res)
+		case err != nil && tc.result != nil:
+			t.Errorf("ERROR: %q: was expected to return %q: %s", exprs, tc.result, err)
+		case tc.result.Equal(res):
+			t.Logf("PASS: %q → %q", exprs, res)
+		default:
+			t.Errorf("ERROR: %q: got %q expected %q", exprs, res, tc.result)
+		}
+	}
+}
@@ -0,0 +1,400 @@
+package javalanche
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// Checker infers the types of expressions before they run,
+// reporting operators applied to types that don't support
+// them. Types are only known for literals, variables with
+// a type annotation and what's computed from them
+type Checker struct {
+	vars map[string]ValueType
+	ctx  *Javalanche
+}
+
+// NewChecker creates a Checker with no known variables
+func NewChecker() *Checker {
+	return &Checker{
+		vars: make(map[string]ValueType),
+		ctx:  &Javalanche{},
+	}
+}
+
+// Check checks a statement, remembering the annotated
+// types of the variables it declares
+func (c *Checker) Check(node Node) error {
+	_, err := c.typeOf(node)
+	return err
+}
+
+// typeOf returns the type a node evaluates to,
+// or ValueTypeUnknown if it can't be known
+func (c *Checker) typeOf(node Node) (ValueType, error) {
+	switch n := node.(type) {
+	case nil:
+		return ValueTypeUnknown, nil
+	case Value:
+		return n.Type(), nil
+	case *Variable:
+		return c.vars[n.Name], nil
+	case *AnnotatedVariable:
+		return n.Type, nil
+	case *BinaryExpression:
+		return c.binaryType(n)
+	case *UnaryExpression:
+		return c.unaryType(n)
+	case *ConditionalExpression:
+		return c.conditionalType(n)
+	case *AssignExpression:
+		return c.assignType(n)
+	case *ConstNode:
+		return c.typeOf(n.Assign)
+	case *ListExpression:
+		if err := c.checkAll(n.Items...); err != nil {
+			return ValueTypeUnknown, err
+		}
+		return ValueTypeList, nil
+	case *IndexExpression:
+		return c.indexType(n.Expr, n.Optional, n.Index)
+	case *SliceExpression:
+		return c.indexType(n.Expr, n.Optional, n.Start, n.End)
+	case *MemberExpression:
+		return ValueTypeUnknown, c.checkAll(n.Expr)
+	case *LambdaExpression:
+		return ValueTypeFunction, c.checkLambda(n)
+	case *FunctionNode:
+		return ValueTypeNull, c.checkFunction(n)
+	case *ClassNode:
+		return ValueTypeNull, c.checkClass(n)
+	case *CallExpression:
+		return ValueTypeUnknown, c.checkAll(append([]Node{n.Callee}, n.Args...)...)
+	case *PrintNode:
+		return ValueTypeNull, c.checkAll(n.Nodes...)
+	case BodyNode:
+		return c.bodyType(n)
+	case *IfElseNode:
+		return ValueTypeUnknown, c.checkAll(n.Condition, n.TrueBody, n.FalseBody)
+	case *ForNode:
+		return ValueTypeUnknown, c.checkAll(n.Condition, n.Body)
+	case *ForInNode:
+		return ValueTypeUnknown, c.checkAll(n.Values, n.Body)
+	case *YieldNode:
+		return ValueTypeNull, c.checkAll(n.Value)
+	case *ThrowNode:
+		return ValueTypeUnknown, c.checkAll(n.Value)
+	case *TryNode:
+		return ValueTypeUnknown, c.checkTry(n)
+	case *MatchNode:
+		return ValueTypeUnknown, c.checkMatch(n)
+	default:
+		return ValueTypeUnknown, nil
+	}
+}
+
+// checkAll checks nodes whose types don't matter
+func (c *Checker) checkAll(nodes ...Node) error {
+	for _, node := range nodes {
+		if _, err := c.typeOf(node); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *Checker) bodyType(body BodyNode) (ValueType, error) {
+	t := ValueTypeNull
+	for _, node := range body {
+		var err error
+		if t, err = c.typeOf(node); err != nil {
+			return ValueTypeUnknown, err
+		}
+	}
+	return t, nil
+}
+
+// checkFunction checks the body of a function knowing the
+// annotated types of its parameters, and that it returns the
+// annotated result type. Other parameters hide the globals
+// with the same name
+func (c *Checker) checkFunction(n *FunctionNode) error {
+	t, err := c.params(n.Params, n.Types).bodyType(n.Body)
+	switch {
+	case err != nil:
+		return err
+	case n.Generator, n.Result == ValueTypeUnknown, t == ValueTypeUnknown, t == n.Result:
+		return nil
+	default:
+		return &ErrTypeMismatch{
+			Pos:    n.Pos,
+			Reason: fmt.Sprintf("%s returns %s, not %s", n.Name, t, n.Result),
+		}
+	}
+}
+
+// checkTry checks a try statement. Errors in a body with a
+// catch aren't reported, so they fail when running and can
+// be caught
+func (c *Checker) checkTry(n *TryNode) error {
+	if n.Name == "" {
+		return c.checkAll(n.Body, n.Catch, n.Finally)
+	}
+
+	_, _ = c.typeOf(n.Body)
+
+	// the error is only known inside the catch body
+	local := c.child()
+	local.vars[n.Name] = ValueTypeError
+	if err := local.checkAll(n.Catch); err != nil {
+		return err
+	}
+	return c.checkAll(n.Finally)
+}
+
+// child creates a Checker for a nested scope, starting with
+// the variables known so far
+func (c *Checker) child() *Checker {
+	local := &Checker{
+		vars: make(map[string]ValueType, len(c.vars)),
+		ctx:  c.ctx,
+	}
+	for name, t := range c.vars {
+		local.vars[name] = t
+	}
+	return local
+}
+
+// checkClass checks the default values of the fields, and
+// the methods, where this and super are objects
+func (c *Checker) checkClass(n *ClassNode) error {
+	if err := c.checkAll(n.Defaults...); err != nil {
+		return err
+	}
+
+	local := c.params([]string{"this", "super"}, nil)
+	for _, m := range n.Methods {
+		if err := local.checkFunction(m); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkLambda checks the body of a lambda like the one
+// of a function without annotations
+func (c *Checker) checkLambda(n *LambdaExpression) error {
+	if n.Body == nil {
+		return nil
+	}
+	return c.params(n.Params, nil).checkAll(n.Body)
+}
+
+// params creates a Checker for the body of a function, where
+// the parameters have their annotated types, if any
+func (c *Checker) params(names []string, types []ValueType) *Checker {
+	local := c.child()
+	for i, p := range names {
+		local.vars[p] = ValueTypeUnknown
+		if i < len(types) {
+			local.vars[p] = types[i]
+		}
+	}
+	return local
+}
+
+func (c *Checker) checkMatch(n *MatchNode) error {
+	if err := c.checkAll(n.Subject, n.Else); err != nil {
+		return err
+	}
+	for _, mc := range n.Cases {
+		if err := c.checkAll(mc.Guard, mc.Body); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *Checker) binaryType(n *BinaryExpression) (ValueType, error) {
+	if n.Op == "=" {
+		return c.assignType(&AssignExpression{
+			Targets: []Node{n.Left},
+			Values:  []Node{n.Right},
+			Pos:     n.Pos,
+		})
+	}
+
+	left, err := c.typeOf(n.Left)
+	if err != nil {
+		return ValueTypeUnknown, err
+	}
+	right, err := c.typeOf(n.Right)
+	if err != nil {
+		return ValueTypeUnknown, err
+	}
+
+	switch n.Op {
+	case "==", "!=", "===", "!==":
+		return ValueTypeBool, nil
+	case "??":
+		switch left {
+		case ValueTypeUnknown:
+			return ValueTypeUnknown, nil
+		case ValueTypeNull:
+			return right, nil
+		default:
+			return left, nil
+		}
+	}
+
+	l, r := sampleValue(left), sampleValue(right)
+	if l == nil || r == nil {
+		return ValueTypeUnknown, nil
+	}
+
+	res, err := n.evalOperator(l, r)
+	if err != nil {
+		return ValueTypeUnknown, &ErrTypeMismatch{
+			Pos:    n.Pos,
+			Reason: fmt.Sprintf("operator %q can't be used on %s and %s", n.Op, left, right),
+		}
+	}
+	return res.Type(), nil
+}
+
+func (c *Checker) unaryType(n *UnaryExpression) (ValueType, error) {
+	t, err := c.typeOf(n.Expr)
+	if err != nil {
+		return ValueTypeUnknown, err
+	}
+
+	v := sampleValue(t)
+	if v == nil {
+		return ValueTypeUnknown, nil
+	}
+
+	var res Value
+	switch n.Op {
+	case "++", "--":
+		// like adding or subtracting 1, without assigning
+		_, err = (&BinaryExpression{Op: n.Op[:1]}).evalOperator(v, NewInteger(1))
+		res = NewNull()
+	default:
+		res, err = n.evalOperator(c.ctx, v)
+	}
+
+	if err != nil {
+		return ValueTypeUnknown, &ErrTypeMismatch{
+			Pos:    n.Pos,
+			Reason: fmt.Sprintf("operator %q can't be used on %s", n.Op, t),
+		}
+	}
+	return res.Type(), nil
+}
+
+func (c *Checker) conditionalType(n *ConditionalExpression) (ValueType, error) {
+	if err := c.checkAll(n.Condition); err != nil {
+		return ValueTypeUnknown, err
+	}
+
+	then, err := c.typeOf(n.Then)
+	if err != nil {
+		return ValueTypeUnknown, err
+	}
+	otherwise, err := c.typeOf(n.Else)
+	if err != nil {
+		return ValueTypeUnknown, err
+	}
+
+	if then != otherwise {
+		return ValueTypeUnknown, nil
+	}
+	return then, nil
+}
+
+// assignType checks the values match the annotated types of
+// the targets, and remembers new annotations
+func (c *Checker) assignType(n *AssignExpression) (ValueType, error) {
+	values := make([]ValueType, len(n.Values))
+	for i, node := range n.Values {
+		t, err := c.typeOf(node)
+		if err != nil {
+			return ValueTypeUnknown, err
+		}
+		values[i] = t
+	}
+
+	for i, target := range n.Targets {
+		declared, err := c.typeOf(target)
+		if err != nil {
+			return ValueTypeUnknown, err
+		}
+
+		if i < len(values) && len(values) == len(n.Targets) {
+			if got := values[i]; declared != ValueTypeUnknown &&
+				got != ValueTypeUnknown && got != declared {
+				name, _ := assignedName(target)
+				return ValueTypeUnknown, &ErrTypeMismatch{
+					Pos:    n.Pos,
+					Reason: fmt.Sprintf("can't assign %s to %s of type %s", got, name, declared),
+				}
+			}
+		}
+
+		if v, ok := target.(*AnnotatedVariable); ok {
+			c.vars[v.Variable.Name] = v.Type
+		}
+	}
+
+	return ValueTypeNull, nil
+}
+
+// indexType checks an index or slice and returns the type
+// of the result when known
+func (c *Checker) indexType(expr Node, optional bool, index ...Node) (ValueType, error) {
+	t, err := c.typeOf(expr)
+	if err != nil {
+		return ValueTypeUnknown, err
+	}
+	if err := c.checkAll(index...); err != nil {
+		return ValueTypeUnknown, err
+	}
+
+	switch {
+	case optional:
+		// could be null
+		return ValueTypeUnknown, nil
+	case t == ValueTypeString:
+		return t, nil
+	case t == ValueTypeList && len(index) == 2:
+		// slice
+		return t, nil
+	default:
+		return ValueTypeUnknown, nil
+	}
+}
+
+// sampleValue returns a value of the given type to try
+// operators with, or nil if the type is unknown
+func sampleValue(t ValueType) Value {
+	switch t {
+	case ValueTypeInt:
+		return NewInteger(1)
+	case ValueTypeFloat:
+		return NewFloat(1.5)
+	case ValueTypeDecimal:
+		return NewDecimal(big.NewInt(15), 1)
+	case ValueTypeString:
+		return NewString("a")
+	case ValueTypeBool:
+		return NewBoolean(true)
+	case ValueTypeList:
+		return NewList()
+	case ValueTypeRange:
+		return NewRange(0, 1, false)
+	case ValueTypeNull:
+		return NewNull()
+	default:
+		return nil
+	}
+}
@@ -1,18 +1,73 @@
 package javalanche
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
 
 var (
 	_ error = (*ErrInvalidToken)(nil)
 	_ error = (*ErrInvalidValue)(nil)
+	_ error = (*ErrTypeMismatch)(nil)
+	_ Error = (*RuntimeError)(nil)
+	_ Error = (*SyntaxError)(nil)
+	_ Error = (*TypeError)(nil)
+	_ Error = (*NameError)(nil)
+	_ Error = (*ZeroDivisionError)(nil)
+	_ Error = (*IndexError)(nil)
+	_ Error = (*LimitError)(nil)
+	_ Error = (*ErrorValue)(nil)
+)
+
+// ErrorCode identifies the kind of an Error. Codes don't
+// change between versions, so they can be stored or sent
+type ErrorCode string
+
+const (
+	// CodeSyntax is for statements that can't be parsed
+	CodeSyntax ErrorCode = "syntax_error"
+	// CodeType is for values of the wrong type
+	CodeType ErrorCode = "type_error"
+	// CodeName is for undefined variables
+	CodeName ErrorCode = "name_error"
+	// CodeZeroDivision is for divisions by zero
+	CodeZeroDivision ErrorCode = "zero_division_error"
+	// CodeIndex is for indexes beyond the end of a sequence
+	CodeIndex ErrorCode = "index_error"
+	// CodeLimit is for scripts going beyond the limits of
+	// the interpreter, like the call depth
+	CodeLimit ErrorCode = "limit_error"
+	// CodeThrown is for errors thrown by scripts
+	CodeThrown ErrorCode = "thrown_error"
+	// CodeRuntime is for any other error of a running script
+	CodeRuntime ErrorCode = "runtime_error"
 )
 
+// Error is implemented by the errors EvalLine returns,
+// which are a *SyntaxError, a *TypeError found before
+// running, or a *RuntimeError. Position isn't valid when
+// unknown
+type Error interface {
+	error
+	Code() ErrorCode
+	Position() Position
+}
+
+// positioner sets the position of an error, if unknown
+type positioner interface {
+	at(pos Position)
+}
+
 type ErrInvalidToken struct {
 	Token  *Token
 	Reason string
 }
 
 func (e ErrInvalidToken) Error() string {
+	if e.Token != nil && e.Token.Pos.IsValid() {
+		return fmt.Sprintf("InvalidToken: %#v, Reason: %s, Position: %s", e.Token, e.Reason, e.Token.Pos)
+	}
 	return fmt.Sprintf("InvalidToken: %#v, Reason: %s", e.Token, e.Reason)
 }
 
@@ -23,3 +78,355 @@ type ErrInvalidValue struct {
 func (e ErrInvalidValue) Error() string {
 	return fmt.Sprintf("InvalidValue: %q", e.Value)
 }
+
+// ErrTypeMismatch is reported by the Checker for operations
+// that can't work on the types of their operands
+type ErrTypeMismatch struct {
+	Pos    Position
+	Reason string
+}
+
+func (e ErrTypeMismatch) Error() string {
+	if e.Pos.IsValid() {
+		return fmt.Sprintf("TypeMismatch: %s, Position: %s", e.Reason, e.Pos)
+	}
+	return fmt.Sprintf("TypeMismatch: %s", e.Reason)
+}
+
+// maxTraceLines limits the frames shown by StackTrace
+const maxTraceLines = 20
+
+// RuntimeError is an error of a running script. Pos is where
+// it happened, when known, and Trace lists the blocks and
+// calls it went through, innermost first
+type RuntimeError struct {
+	Err   error
+	Pos   Position
+	Trace []Frame
+}
+
+// Frame is a block or call a RuntimeError went through
+type Frame struct {
+	Name string
+	Pos  Position
+}
+
+// String returns the frame like `call add at 3:5`
+func (f Frame) String() string {
+	return fmt.Sprintf("%s at %s", f.Name, f.Pos)
+}
+
+func (e *RuntimeError) Error() string {
+	return e.Err.Error()
+}
+
+// Code returns the code of the error that happened,
+// or CodeRuntime
+func (e *RuntimeError) Code() ErrorCode {
+	var inner Error
+	if errors.As(e.Err, &inner) {
+		return inner.Code()
+	}
+	return CodeRuntime
+}
+
+func (e *RuntimeError) Position() Position {
+	return e.Pos
+}
+
+func (e *RuntimeError) Unwrap() error {
+	return e.Err
+}
+
+// StackTrace returns the error and where it happened,
+// followed by a line for each frame
+func (e *RuntimeError) StackTrace() string {
+	var sb strings.Builder
+
+	sb.WriteString(e.Error())
+	if e.Pos.IsValid() {
+		fmt.Fprintf(&sb, " at %s", e.Pos)
+	}
+
+	for i, f := range e.Trace {
+		if i == maxTraceLines {
+			fmt.Fprintf(&sb, "\n  ... %v more", len(e.Trace)-i)
+			break
+		}
+		fmt.Fprintf(&sb, "\n  in %s", f)
+	}
+	return sb.String()
+}
+
+// atPosition records where a runtime error happened,
+// unless it's known already
+func atPosition(err error, pos Position) error {
+	var re *RuntimeError
+	if err == nil || !pos.IsValid() || !isCatchable(err) || errors.As(err, &re) {
+		return err
+	}
+	return &RuntimeError{Err: classify(err, pos), Pos: pos}
+}
+
+// runtimeError wraps an error of a running script into a
+// RuntimeError, unless it's one already
+func runtimeError(err error) error {
+	var re *RuntimeError
+	if err == nil || errors.As(err, &re) {
+		return err
+	}
+	return &RuntimeError{Err: classify(err, Position{})}
+}
+
+// traced adds a frame to the trace of a runtime error. Errors
+// wrapping a RuntimeError keep its position and trace
+func traced(err error, name string, pos Position) error {
+	if err == nil || !pos.IsValid() || !isCatchable(err) {
+		return err
+	}
+
+	re, ok := err.(*RuntimeError)
+	if !ok {
+		re = &RuntimeError{Err: classify(err, Position{})}
+
+		var inner *RuntimeError
+		if errors.As(err, &inner) {
+			re.Pos = inner.Pos
+			re.Trace = append([]Frame(nil), inner.Trace...)
+		}
+	}
+
+	re.Trace = append(re.Trace, Frame{Name: name, Pos: pos})
+	return re
+}
+
+// classify wraps the errors of the interpreter into the
+// exported type they belong to, setting the position if
+// it's not known yet
+func classify(err error, pos Position) error {
+	var e Error
+	if errors.As(err, &e) {
+		if p, ok := e.(positioner); ok {
+			p.at(pos)
+		}
+		return err
+	}
+
+	var tm *ErrTypeMismatch
+	switch {
+	case errors.As(err, &tm):
+		return &TypeError{Pos: tm.Pos, Err: err}
+	case errors.Is(err, errDivZero):
+		return &ZeroDivisionError{Pos: pos, Err: err}
+	case errors.Is(err, errIndexRange):
+		return &IndexError{Pos: pos, Err: err}
+	case errors.Is(err, errCallDepth), errors.Is(err, errOverflow), errors.Is(err, errTooLarge), errors.Is(err, errInterrupted):
+		return &LimitError{Pos: pos, Err: err}
+	case errors.Is(err, errInvalidTypes), errors.Is(err, errNotInteger), errors.Is(err, errNull):
+		return &TypeError{Pos: pos, Err: err}
+	default:
+		return err
+	}
+}
+
+// SyntaxError is a statement that can't be parsed
+type SyntaxError struct {
+	Pos Position
+	Err error
+}
+
+// newSyntaxError wraps an error of the lexer or the parser,
+// at the position of the invalid token if known
+func newSyntaxError(err error) *SyntaxError {
+	e := &SyntaxError{Err: err}
+
+	var it *ErrInvalidToken
+	if errors.As(err, &it) && it.Token != nil {
+		e.Pos = it.Token.Pos
+	}
+	return e
+}
+
+func (e *SyntaxError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *SyntaxError) Unwrap() error {
+	return e.Err
+}
+
+func (e *SyntaxError) Code() ErrorCode {
+	return CodeSyntax
+}
+
+func (e *SyntaxError) Position() Position {
+	return e.Pos
+}
+
+// Is matches any SyntaxError, so errors.Is(err, &SyntaxError{}) works
+func (e *SyntaxError) Is(target error) bool {
+	_, ok := target.(*SyntaxError)
+	return ok
+}
+
+// TypeError is an operation or assignment given values of
+// the wrong type, found before running or while running
+type TypeError struct {
+	Pos Position
+	Err error
+}
+
+// typeErrorf creates a TypeError with the formatted message
+func typeErrorf(format string, args ...any) error {
+	return &TypeError{Err: fmt.Errorf(format, args...)}
+}
+
+func (e *TypeError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *TypeError) Unwrap() error {
+	return e.Err
+}
+
+func (e *TypeError) Code() ErrorCode {
+	return CodeType
+}
+
+func (e *TypeError) Position() Position {
+	return e.Pos
+}
+
+// Is matches any TypeError, so errors.Is(err, &TypeError{}) works
+func (e *TypeError) Is(target error) bool {
+	_, ok := target.(*TypeError)
+	return ok
+}
+
+func (e *TypeError) at(pos Position) {
+	if !e.Pos.IsValid() {
+		e.Pos = pos
+	}
+}
+
+// NameError is the use of an undefined variable
+type NameError struct {
+	Name string
+	Pos  Position
+	Err  error
+}
+
+func (e *NameError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *NameError) Unwrap() error {
+	return e.Err
+}
+
+func (e *NameError) Code() ErrorCode {
+	return CodeName
+}
+
+func (e *NameError) Position() Position {
+	return e.Pos
+}
+
+// Is matches any NameError, so errors.Is(err, &NameError{}) works
+func (e *NameError) Is(target error) bool {
+	_, ok := target.(*NameError)
+	return ok
+}
+
+func (e *NameError) at(pos Position) {
+	if !e.Pos.IsValid() {
+		e.Pos = pos
+	}
+}
+
+// ZeroDivisionError is a division or modulo by zero
+type ZeroDivisionError struct {
+	Pos Position
+	Err error
+}
+
+func (e *ZeroDivisionError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *ZeroDivisionError) Unwrap() error {
+	return e.Err
+}
+
+func (e *ZeroDivisionError) Code() ErrorCode {
+	return CodeZeroDivision
+}
+
+func (e *ZeroDivisionError) Position() Position {
+	return e.Pos
+}
+
+// Is matches any ZeroDivisionError, so errors.Is(err, &ZeroDivisionError{}) works
+func (e *ZeroDivisionError) Is(target error) bool {
+	_, ok := target.(*ZeroDivisionError)
+	return ok
+}
+
+// IndexError is an index beyond the end of a list, a string
+// or a range
+type IndexError struct {
+	Pos Position
+	Err error
+}
+
+func (e *IndexError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *IndexError) Unwrap() error {
+	return e.Err
+}
+
+func (e *IndexError) Code() ErrorCode {
+	return CodeIndex
+}
+
+func (e *IndexError) Position() Position {
+	return e.Pos
+}
+
+// Is matches any IndexError, so errors.Is(err, &IndexError{}) works
+func (e *IndexError) Is(target error) bool {
+	_, ok := target.(*IndexError)
+	return ok
+}
+
+// LimitError is a script going beyond the limits of the
+// interpreter, like the maximum call depth, or integers
+// not fitting an int with StrictIntegers
+type LimitError struct {
+	Pos Position
+	Err error
+}
+
+func (e *LimitError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *LimitError) Unwrap() error {
+	return e.Err
+}
+
+func (e *LimitError) Code() ErrorCode {
+	return CodeLimit
+}
+
+func (e *LimitError) Position() Position {
+	return e.Pos
+}
+
+// Is matches any LimitError, so errors.Is(err, &LimitError{}) works
+func (e *LimitError) Is(target error) bool {
+	_, ok := target.(*LimitError)
+	return ok
+}
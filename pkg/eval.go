@@ -5,25 +5,143 @@ import (
 	"fmt"
 )
 
-// SetValue Assigns value to given variable
+// maxCallDepth limits the nesting of function calls
+const maxCallDepth = 1000
+
+// scope holds the local variables of a function call,
+// and the scope the function was defined in. Block scopes
+// only hold the error of a catch, new variables go to
+// the scope around them
+type scope struct {
+	declarations
+	vars   map[string]Value
+	parent *scope
+	block  bool
+}
+
+// declarations are the constants and the annotated types
+// of the variables of a scope, or of the globals
+type declarations struct {
+	consts map[string]bool
+	types  map[string]ValueType
+}
+
+// setConst makes the variable constant
+func (d *declarations) setConst(name string) {
+	if d.consts == nil {
+		d.consts = make(map[string]bool)
+	}
+	d.consts[name] = true
+}
+
+// setType makes the variable only accept the given type
+func (d *declarations) setType(name string, t ValueType) {
+	if d.types == nil {
+		d.types = make(map[string]ValueType)
+	}
+	d.types[name] = t
+}
+
+// checkAssign rejects assigning to constants, and values not
+// matching the type the variable was annotated with
+func (d *declarations) checkAssign(name string, v Value) error {
+	if d.consts[name] {
+		return fmt.Errorf("constant %q %w", name, errConstant)
+	}
+	if t, ok := d.types[name]; ok && v.Type() != t {
+		return typeErrorf("can't assign %s to %q of type %s", v.Type(), name, t)
+	}
+	return nil
+}
+
+// lookup finds the innermost scope defining the variable
+func (sc *scope) lookup(name string) *scope {
+	for ; sc != nil; sc = sc.parent {
+		if _, ok := sc.vars[name]; ok {
+			return sc
+		}
+	}
+	return nil
+}
+
+// local finds the innermost scope that isn't a block
+func (sc *scope) local() *scope {
+	for sc != nil && sc.block {
+		sc = sc.parent
+	}
+	return sc
+}
+
+// SetValue Assigns value to given variable, unless it's
+// a constant or the value has the wrong type. Inside a
+// function, variables that aren't global are local
 func (ctx *Javalanche) SetValue(name string, v Value) error {
+	decl, vars := ctx.scopeOf(name)
+	if err := decl.checkAssign(name, v); err != nil {
+		return err
+	}
+	vars[name] = v
+	return nil
+}
+
+// scopeOf returns the declarations and the variables of the
+// scope assigning the variable changes
+func (ctx *Javalanche) scopeOf(name string) (*declarations, map[string]Value) {
+	if sc := ctx.scope.lookup(name); sc != nil {
+		return &sc.declarations, sc.vars
+	}
+	if _, ok := ctx.Variable[name]; !ok {
+		if sc := ctx.scope.local(); sc != nil {
+			return &sc.declarations, sc.vars
+		}
+	}
+	return &ctx.declarations, ctx.Variable
+}
+
+// SetConst assigns a value scripts can read but not reassign
+func (ctx *Javalanche) SetConst(name string, v Value) error {
+	if !isIdentifier(name) {
+		return fmt.Errorf("invalid constant name %q", name)
+	}
 	ctx.Variable[name] = v
+	ctx.setConst(name)
 	return nil
 }
 
+// IsConst tells if a variable is a constant
+func (ctx *Javalanche) IsConst(name string) bool {
+	return ctx.consts[name]
+}
+
 // GetValue retrieves Value of given variable
 func (ctx *Javalanche) GetValue(name string) (Value, error) {
+	if sc := ctx.scope.lookup(name); sc != nil {
+		return sc.vars[name], nil
+	}
 	if v, ok := ctx.Variable[name]; ok {
 		return v, nil
 
 	}
-	return nil, fmt.Errorf("variable %q not found", name)
+	return nil, &NameError{Name: name, Err: fmt.Errorf("variable %q %w", name, errNotFound)}
 }
 
-// EvalLine evaluates expression lines
+// checkInteger rejects integers beyond the int range
+// when StrictIntegers is set
+func (ctx *Javalanche) checkInteger(v Value) (Value, error) {
+	if _, ok := v.(*BigIntegerLiteral); ok && ctx.StrictIntegers {
+		return nil, errOverflow
+	}
+	return v, nil
+}
+
+// EvalLine evaluates expression lines. Interrupting it stops
+// the statement being evaluated and the ones after it
 func (ctx *Javalanche) EvalLine(lines ...string) (Value, error) {
 	ctx.mu.Lock()
 	defer ctx.mu.Unlock()
+
+	// forget interruptions of the previous call
+	ctx.state.CompareAndSwap(stateInterrupted, stateRunning)
 	if err := ctx.ParseLine(lines...); err != nil {
 		return nil, err
 	}
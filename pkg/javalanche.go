@@ -2,10 +2,10 @@
 package javalanche
 
 import (
-	"bytes"
 	"errors"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
@@ -18,20 +18,47 @@ var (
 type Javalanche struct {
 	Variable map[string]Value
 
-	buf    bytes.Buffer
+	// StrictIntegers makes integer results that don't fit in
+	// an int fail instead of being promoted to big integers
+	StrictIntegers bool
+
+	// Decimal sets the precision and rounding of decimal results
+	Decimal DecimalContext
+
+	declarations
+	scope  *scope
+	depth  int
+	gen    *generatorRun
+	buf    *lineBuffer
 	mu     sync.Mutex
 	lexer  *Tokenizer
 	parser *Parser
+
+	// state is running, interrupted or closed
+	state atomic.Int32
 }
 
+const (
+	stateRunning int32 = iota
+	stateInterrupted
+	stateClosed
+)
+
 // New Creates the new instance of Javalanche
 func New() *Javalanche {
+	return newJavalanche(DefaultParserTimeout)
+}
+
+// newJavalanche creates an interpreter whose parser waits the
+// given time for more tokens before giving the result
+func newJavalanche(timeout time.Duration) *Javalanche {
 	ctx := &Javalanche{
 		Variable: make(map[string]Value),
+		buf:      newLineBuffer(),
+		Decimal:  DefaultDecimalContext,
 	}
 
-	timeout := 100 * time.Millisecond
-	ctx.lexer = NewTokenizer(&ctx.buf)
+	ctx.lexer = NewTokenizer(ctx.buf)
 	ctx.parser = NewParser(ctx.lexer, ctx, timeout)
 
 	go ctx.lexer.Run()
@@ -40,14 +67,38 @@ func New() *Javalanche {
 	return ctx
 }
 
-// ParseLine feeds the parser with a new line of javalanche
+// Interrupt makes the statement being evaluated fail with a
+// LimitError the next time it loops or calls a function, so
+// scripts that don't end can be stopped from another goroutine
+func (ctx *Javalanche) Interrupt() {
+	ctx.state.CompareAndSwap(stateRunning, stateInterrupted)
+}
+
+// Close stops the interpreter, interrupting the statement being
+// evaluated and letting its goroutines finish. EvalLine fails
+// with io.EOF afterwards
+func (ctx *Javalanche) Close() error {
+	ctx.state.Store(stateClosed)
+	ctx.parser.Close()
+	return ctx.buf.Close()
+}
+
+// interrupted returns errInterrupted once Interrupt or Close
+// have been called
+func (ctx *Javalanche) interrupted() error {
+	if ctx != nil && ctx.state.Load() != stateRunning {
+		return errInterrupted
+	}
+	return nil
+}
+
+// ParseLine feeds the parser with a new line of javalanche.
+// Empty lines and indentation are kept so token positions
+// match the source
 func (ctx *Javalanche) ParseLine(lines ...string) error {
 	for _, line := range lines {
-		line = strings.TrimSpace(line)
-		if line != "" {
-			ctx.buf.WriteString(line)
-			ctx.buf.WriteRune('\n')
-		}
+		line = strings.TrimRight(line, "\r\n")
+		ctx.buf.WriteString(line + "\n")
 	}
 
 	return nil
@@ -1,8 +1,10 @@
 package javalanche
 
 import (
+	"fmt"
 	"io"
 	"os"
+	"sync"
 	"time"
 )
 
@@ -15,10 +17,14 @@ const (
 type Parser struct {
 	tokenizer *Tokenizer
 	ctx       *Javalanche
+	checker   *Checker
 	timeout   time.Duration
 	stage     Stage
 	result    ParserResult
+	lexErr    error
 	outCh     chan ParserResult
+	done      chan struct{}
+	closeOnce sync.Once
 }
 
 // ParserResult represents our resul struct
@@ -36,11 +42,21 @@ func NewParser(tokenizer *Tokenizer, ctx *Javalanche, timeout time.Duration) *Pa
 	return &Parser{
 		tokenizer: tokenizer,
 		ctx:       ctx,
+		checker:   NewChecker(),
 		timeout:   timeout,
 		outCh:     make(chan ParserResult),
+		done:      make(chan struct{}),
 	}
 }
 
+// Close stops waiting for results to be read, so Run can
+// finish once the tokenizer does
+func (p *Parser) Close() {
+	p.closeOnce.Do(func() {
+		close(p.done)
+	})
+}
+
 // Results returns the channel to watch for parser results
 func (p *Parser) Results() <-chan ParserResult {
 	return p.outCh
@@ -54,30 +70,46 @@ func (p *Parser) IsEmpty() bool {
 // GetPrecedence function to get the precedence of a token
 func getOperatorPrecedence(op string) int {
 	switch op {
-	case "=":
+	case "=", "=>", "->":
 		return 1
-	case "or", "||":
+	case "?", ":":
 		return 2
-	case "and", "&&":
+	case "??":
 		return 3
-	case "==":
+	case "or", "||":
 		return 4
-	case "!=":
+	case "and", "&&":
 		return 5
-	case "<", ">", "<=", ">=":
+	case "==", "===":
 		return 6
-	case "+":
+	case "!=", "!==":
 		return 7
-	case "-":
+	case "<", ">", "<=", ">=", "in", "by":
 		return 8
-	case "*", "/", "%":
+	case "..", "..<":
 		return 9
-	case "^":
+	case "|":
 		return 10
-	case "!":
+	case "xor":
 		return 11
-	default:
+	case "&":
 		return 12
+	case "<<", ">>":
+		return 13
+	case "+":
+		return 14
+	case "-":
+		return 15
+	case "*", "/", "%":
+		return 16
+	case "^":
+		return 17
+	case "!", "~":
+		return 18
+	case ".", "?.":
+		return 19
+	default:
+		return 20
 	}
 }
 
@@ -114,6 +146,7 @@ func findHighestPrecedenceOperator(nodes []StageNode) (int, *Token) {
 
 // Run starts the parser
 func (p *Parser) Run() {
+	defer close(p.outCh)
 
 	for {
 		token, err := p.tokenizer.NextToken(p.timeout)
@@ -140,10 +173,13 @@ func (p *Parser) applyError(err error) bool {
 
 	p.Println("applyError:", err)
 
-	switch err {
-	case io.EOF:
+	switch {
+	case err == io.EOF:
 		// terminate
 		terminate = true
+	case p.lexErr == nil:
+		// bad token, the statement fails at the end of the line
+		p.lexErr = err
 	}
 
 	return terminate
@@ -152,29 +188,73 @@ func (p *Parser) applyError(err error) bool {
 // ApplyEOL parses tokens whe EOL token is found
 func (p *Parser) applyEOL() {
 	p.PrintDetails("applyEOL")
+	defer p.recoverPanic()
+
+	switch {
+	case p.lexErr != nil:
+		// the line had bad tokens, discard the statement
+		p.stage.Reset()
+		p.result = ParserResult{nil, newSyntaxError(p.lexErr)}
+		p.lexErr = nil
+		return
+	case p.IsEmpty():
+		// empty line
+		p.result = ParserResult{}
+		return
+	}
 
 	node, err := p.stage.Parse()
 	if err != nil {
 		// Fail to parse
 		p.Println("applyEOL:", "Stage.Parse:", "err:", err)
+		if err != ErrMoreData {
+			err = newSyntaxError(err)
+		}
 		p.result = ParserResult{nil, err}
 		return
 	}
 
 	p.Println("applyEOL:", "Stage.Parse:", node)
 
-	// Call Eval directly on each Node
+	// reject type mismatches before running anything
+	if err := p.checker.Check(node); err != nil {
+		p.Println("applyEOL:", "Check:", "err:", err)
+		p.result = ParserResult{nil, classify(err, Position{})}
+		return
+	}
+
+	// Call Eval directly on each Node, unless the EvalLine
+	// call was interrupted by an earlier statement
+	if err := p.ctx.interrupted(); err != nil {
+		p.result = ParserResult{nil, runtimeError(err)}
+		return
+	}
 	value, err := node.Eval(p.ctx)
 	switch {
 	case err != nil:
 		p.Println("applyEOL:", node, "→ err:", err)
-		p.result = ParserResult{nil, err}
+		p.result = ParserResult{nil, runtimeError(err)}
 	default:
 		p.Println("applyEOL:", node, "→", value)
 		p.result = ParserResult{value, nil}
 	}
 }
 
+// recoverPanic turns a panic while parsing or evaluating a
+// statement into its error, so the interpreter keeps running
+func (p *Parser) recoverPanic() {
+	r := recover()
+	if r == nil {
+		return
+	}
+
+	p.Println("applyEOL:", "panic:", r)
+	p.stage.Reset()
+	p.lexErr = nil
+	p.ctx.scope, p.ctx.depth, p.ctx.gen = nil, 0, nil
+	p.result = ParserResult{nil, runtimeError(fmt.Errorf("%w: %v", errPanic, r))}
+}
+
 // ApplyTimeout appplies timeout
 func (p *Parser) applyTimeout() {
 	var result ParserResult
@@ -189,7 +269,15 @@ func (p *Parser) applyTimeout() {
 		result = ParserResult{nil, nil}
 	}
 
-	p.outCh <- result
+	select {
+	case <-p.done:
+		// closed, nobody is waiting
+	default:
+		select {
+		case p.outCh <- result:
+		case <-p.done:
+		}
+	}
 }
 
 // Eval evaluates
@@ -201,9 +289,12 @@ func (p *Parser) Eval(_ *Javalanche) (Value, error) {
 	return nil, io.EOF
 }
 
-// ApplyToken pushes tokens onto quee
+// ApplyToken pushes tokens onto quee. Leaf tokens with
+// invalid values fail the statement like bad tokens do
 func (p *Parser) applyToken(token *Token) {
 	if token != nil {
-		p.stage.AppendTokens(token)
+		if err := p.stage.AppendTokens(token); err != nil {
+			p.applyError(err)
+		}
 	}
 }
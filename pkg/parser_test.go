@@ -1,16 +1,61 @@
 package javalanche
 
 import (
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"runtime"
 	"strings"
 	"testing"
+	"time"
 )
 
+// newDecimal is a test helper building decimal values
+func newDecimal(s string) Value {
+	d, _ := NewDecimalString(s)
+	return d
+}
+
+// newBigInteger is a test helper building big integer values
+func newBigInteger(s string) Value {
+	n, _ := new(big.Int).SetString(s, 10)
+	return NewBigInteger(n)
+}
+
 func TestParserWithContext(t *testing.T) {
 	type testCase struct {
 		exprs  []string // strings to evaluate in order
 		result Value    // expected Value for the last expression. nil if we expect an error
 	}
 
+	// animalClass declares a class used by several cases
+	animalClass := []string{
+		"class Animal", "name = \"?\"", "sound = \"...\"",
+		"fn init(name) this.name = name end",
+		"fn speak() this.name + \" says \" + this.sound end", "end",
+	}
+	withAnimal := func(exprs ...string) []string {
+		return append(append([]string{}, animalClass...), exprs...)
+	}
+
+	// vecClass declares a class overloading operators
+	vecClass := []string{
+		"class Vec", "x = 0", "y = 0",
+		"fn init(x, y) this.x = x this.y = y end",
+		"fn __add__(o) Vec(this.x + o.x, this.y + o.y) end",
+		"fn __mul__(k) Vec(this.x * k, this.y * k) end",
+		"fn __rmul__(k) this * k end",
+		"fn __eq__(o) this.x == o.x and this.y == o.y end",
+		"fn __neg__() Vec(-this.x, -this.y) end",
+		"fn __lt__(o) this.x < o.x end",
+		"fn __contains__(v) v == this.x or v == this.y end",
+		"end",
+	}
+	withVec := func(exprs ...string) []string {
+		return append(append([]string{}, vecClass...), exprs...)
+	}
+
 	var cases = []testCase{
 		{
 			exprs:  []string{"3"},
@@ -265,51 +310,2309 @@ func TestParserWithContext(t *testing.T) {
 			exprs:  []string{"x = 0", "print if (x >= 0) placeholder =\"hello\"  print(placeholder)", "end", "placeholder"},
 			result: NewString("hello"),
 		},
-	}
-
-	for _, tc := range cases {
-		var retrying bool
-
-	retryLoop:
-		for {
-			var failed bool
-
-			ctx := New()
-
-			exprs := strings.Join(tc.exprs, "\n")
-			res, err := ctx.EvalLine(tc.exprs...)
-
-			switch {
-			case err != nil && tc.result == nil:
-				t.Logf("PASS: %q: failed as expected: %s", exprs, err)
-			case err == nil && tc.result == nil:
-				failed = true
-				t.Errorf("ERROR: %q: should have failed, got %q instead", exprs, res)
-			case err != nil && tc.result != nil:
-				failed = true
-				t.Errorf("ERROR: %q: was expected to return %q: %s", exprs, tc.result, err)
-			case tc.result.Equal(res):
-				t.Logf("PASS: %q → %q", exprs, res)
-			default:
-				failed = true
-				t.Errorf("ERROR: %q: got %q expected %q", exprs, res, tc.result)
-			}
-
-			switch {
-			case !failed:
-				// PASS
-				debugStage = false
-				retrying = false
-				break retryLoop
-			case retrying:
-				// not again
-				debugStage = false
-				break retryLoop
-			default:
-				// oops, again but with logs
-				debugStage = true
-				retrying = true
-			}
-		}
-	}
+		{
+			exprs:  []string{"2 ^ 64"},
+			result: newBigInteger("18446744073709551616"),
+		},
+		{
+			exprs:  []string{"3 ^ 39"},
+			result: NewInteger(4052555153018976267),
+		},
+		{
+			exprs:  []string{"-2 ^ 63"},
+			result: newBigInteger("-9223372036854775808"),
+		},
+		{
+			exprs:  []string{"1 ^ 9223372036854775807"},
+			result: NewInteger(1),
+		},
+		{
+			exprs:  []string{"0 ^ 9223372036854775807"},
+			result: NewInteger(0),
+		},
+		{
+			exprs:  []string{"x = 0 - 1", "x ^ 9223372036854775807"},
+			result: NewInteger(-1),
+		},
+		{
+			exprs:  []string{"x = 0 - 1", "x ^ 9223372036854775806"},
+			result: NewInteger(1),
+		},
+		{
+			exprs:  []string{"2 ^ 100000000"},
+			result: nil,
+		},
+		{
+			exprs:  []string{"(2 ^ 64) ^ 9223372036854775807"},
+			result: nil,
+		},
+		{
+			exprs:  []string{"9223372036854775807 + 1"},
+			result: newBigInteger("9223372036854775808"),
+		},
+		{
+			exprs:  []string{"(0 - 9223372036854775807) - 2"},
+			result: newBigInteger("-9223372036854775809"),
+		},
+		{
+			exprs:  []string{"x = 9223372036854775807", "x++", "x - 1 == 9223372036854775807"},
+			result: NewBoolean(true),
+		},
+		{
+			exprs:  []string{"x = 1", "i = 1", "for (i <= 25)", "x = x * i", "i++", "end", "x"},
+			result: newBigInteger("15511210043330985984000000"),
+		},
+		{
+			exprs:  []string{"100000000000000000000 % 7"},
+			result: NewInteger(2),
+		},
+		{
+			exprs:  []string{"2 ^ 64 > 1.5"},
+			result: NewBoolean(true),
+		},
+		{
+			exprs:  []string{"1.5 < 2 ^ 64"},
+			result: NewBoolean(true),
+		},
+		{
+			exprs:  []string{"2 ^ 64 - 2 ^ 64 + 1"},
+			result: NewInteger(1),
+		},
+		{
+			exprs:  []string{"0.1d + 0.2d == 0.3d"},
+			result: NewBoolean(true),
+		},
+		{
+			exprs:  []string{"0.1d + 0.2d"},
+			result: newDecimal("0.3"),
+		},
+		{
+			exprs:  []string{"12.50d + 1"},
+			result: newDecimal("13.50"),
+		},
+		{
+			exprs:  []string{"x = 19.99d", "3 * x"},
+			result: newDecimal("59.97"),
+		},
+		{
+			exprs:  []string{"10.00d / 4"},
+			result: newDecimal("2.5"),
+		},
+		{
+			exprs:  []string{"2 ^ 64 - 0.5d"},
+			result: newDecimal("18446744073709551615.5"),
+		},
+		{
+			exprs:  []string{"1.5d > 1"},
+			result: NewBoolean(true),
+		},
+		{
+			exprs:  []string{"2 < 2.5d"},
+			result: NewBoolean(true),
+		},
+		{
+			exprs:  []string{"0.5d == 0.5"},
+			result: NewBoolean(true),
+		},
+		{
+			exprs:  []string{"0.1 == 0.1d"},
+			result: NewBoolean(false),
+		},
+		{
+			exprs:  []string{"1.5d + 1.5"},
+			result: nil,
+		},
+		{
+			exprs:  []string{"1.5d / 0"},
+			result: nil,
+		},
+		{
+			exprs:  []string{"0xFF + 0o17 + 0b1010"},
+			result: NewInteger(280),
+		},
+		{
+			exprs:  []string{"0x1_F"},
+			result: NewInteger(31),
+		},
+		{
+			exprs:  []string{"017"},
+			result: NewInteger(17),
+		},
+		{
+			exprs:  []string{"1_000_000"},
+			result: NewInteger(1000000),
+		},
+		{
+			exprs:  []string{"0xFFFFFFFFFFFFFFFF"},
+			result: newBigInteger("18446744073709551615"),
+		},
+		{
+			exprs:  []string{"1e6"},
+			result: NewFloat(1e6),
+		},
+		{
+			exprs:  []string{"1.5e-3"},
+			result: NewFloat(0.0015),
+		},
+		{
+			exprs:  []string{".5 + .25"},
+			result: NewFloat(0.75),
+		},
+		{
+			exprs:  []string{"1_000.50d"},
+			result: newDecimal("1000.50"),
+		},
+		{
+			exprs:  []string{"1.2.3"},
+			result: nil,
+		},
+		{
+			exprs:  []string{"0x"},
+			result: nil,
+		},
+		{
+			exprs:  []string{"0b102"},
+			result: nil,
+		},
+		{
+			exprs:  []string{"1__000"},
+			result: nil,
+		},
+		{
+			exprs:  []string{"1e"},
+			result: nil,
+		},
+		{
+			exprs:  []string{"1.2.3", "1 + 1"},
+			result: NewInteger(2),
+		},
+		{
+			exprs:  []string{"6 & 3"},
+			result: NewInteger(2),
+		},
+		{
+			exprs:  []string{"6 | 3"},
+			result: NewInteger(7),
+		},
+		{
+			exprs:  []string{"6 xor 3"},
+			result: NewInteger(5),
+		},
+		{
+			exprs:  []string{"~5"},
+			result: NewInteger(-6),
+		},
+		{
+			exprs:  []string{"1 << 4"},
+			result: NewInteger(16),
+		},
+		{
+			exprs:  []string{"0 - 16 >> 2"},
+			result: NewInteger(-4),
+		},
+		{
+			exprs:  []string{"1 << 64"},
+			result: newBigInteger("18446744073709551616"),
+		},
+		{
+			exprs:  []string{"(1 << 64) >> 60"},
+			result: NewInteger(16),
+		},
+		{
+			exprs:  []string{"(1 << 64) | 1 & 3"},
+			result: newBigInteger("18446744073709551617"),
+		},
+		{
+			exprs:  []string{"flags = 0b0101", "flags & 0b0100 != 0"},
+			result: NewBoolean(true),
+		},
+		{
+			exprs:  []string{"1 + 2 << 1"},
+			result: NewInteger(6),
+		},
+		{
+			exprs:  []string{"true xor true"},
+			result: NewBoolean(false),
+		},
+		{
+			exprs:  []string{"1.5 & 1"},
+			result: nil,
+		},
+		{
+			exprs:  []string{"1 | 1.5"},
+			result: nil,
+		},
+		{
+			exprs:  []string{"1 << -1"},
+			result: nil,
+		},
+		{
+			exprs:  []string{"1 << 100000000000"},
+			result: nil,
+		},
+		{
+			exprs:  []string{"0 << 100000000000"},
+			result: NewInteger(0),
+		},
+		{
+			exprs:  []string{"(1 << 1000) >> 100000000000"},
+			result: NewInteger(0),
+		},
+		{
+			exprs:  []string{"\"1\" == 1"},
+			result: NewBoolean(true),
+		},
+		{
+			exprs:  []string{"1 == \"1\""},
+			result: NewBoolean(true),
+		},
+		{
+			exprs:  []string{"\"1.5\" == 1.5"},
+			result: NewBoolean(true),
+		},
+		{
+			exprs:  []string{"1.0 == \"1\""},
+			result: NewBoolean(true),
+		},
+		{
+			exprs:  []string{"\"abc\" == 0"},
+			result: NewBoolean(false),
+		},
+		{
+			exprs:  []string{"0 != \"abc\""},
+			result: NewBoolean(true),
+		},
+		{
+			exprs:  []string{"true == \"true\""},
+			result: NewBoolean(false),
+		},
+		{
+			exprs:  []string{"\"true\" == true"},
+			result: NewBoolean(false),
+		},
+		{
+			exprs:  []string{"1 == true"},
+			result: NewBoolean(false),
+		},
+		{
+			exprs:  []string{"true == 1"},
+			result: NewBoolean(false),
+		},
+		{
+			exprs:  []string{"1 == 1.0"},
+			result: NewBoolean(true),
+		},
+		{
+			exprs:  []string{"1 === 1"},
+			result: NewBoolean(true),
+		},
+		{
+			exprs:  []string{"1 === 1.0"},
+			result: NewBoolean(false),
+		},
+		{
+			exprs:  []string{"\"1\" === 1"},
+			result: NewBoolean(false),
+		},
+		{
+			exprs:  []string{"1 !== \"1\""},
+			result: NewBoolean(true),
+		},
+		{
+			exprs:  []string{"2 ^ 64 === 1 << 64"},
+			result: NewBoolean(true),
+		},
+		{
+			exprs:  []string{"0.5d === 0.5"},
+			result: NewBoolean(false),
+		},
+		{
+			exprs:  []string{"\"a\" === \"a\""},
+			result: NewBoolean(true),
+		},
+		{
+			exprs:  []string{"\"apple\" < \"banana\""},
+			result: NewBoolean(true),
+		},
+		{
+			exprs:  []string{"\"b\" >= \"a\""},
+			result: NewBoolean(true),
+		},
+		{
+			exprs:  []string{"\"abc\" <= \"abc\""},
+			result: NewBoolean(true),
+		},
+		{
+			exprs:  []string{"\"Z\" > \"a\""},
+			result: NewBoolean(false),
+		},
+		{
+			exprs:  []string{"\"-\" * 5"},
+			result: NewString("-----"),
+		},
+		{
+			exprs:  []string{"3 * \"ab\""},
+			result: NewString("ababab"),
+		},
+		{
+			exprs:  []string{"\"ab\" * 0"},
+			result: NewString(""),
+		},
+		{
+			exprs:  []string{"\"a\" * 9999999999"},
+			result: nil,
+		},
+		{
+			exprs:  []string{"\"a\" * 99999999999999999999"},
+			result: nil,
+		},
+		{
+			exprs:  []string{"len(\"ab\" * 1000000)"},
+			result: NewInteger(2000000),
+		},
+		{
+			exprs:  []string{"s = \"héllo\"", "s[1]"},
+			result: NewString("é"),
+		},
+		{
+			exprs:  []string{"s = \"héllo\"", "s[-1]"},
+			result: NewString("o"),
+		},
+		{
+			exprs:  []string{"s = \"héllo\"", "s[1:3]"},
+			result: NewString("él"),
+		},
+		{
+			exprs:  []string{"s = \"héllo\"", "s[:2] + s[3:]"},
+			result: NewString("hélo"),
+		},
+		{
+			exprs:  []string{"s = \"héllo\"", "s[-3:100]"},
+			result: NewString("llo"),
+		},
+		{
+			exprs:  []string{"s = \"héllo\"", "s[1 + 1]"},
+			result: NewString("l"),
+		},
+		{
+			exprs:  []string{"s = \"héllo\"", "(s + \"!\")[-1]"},
+			result: NewString("!"),
+		},
+		{
+			exprs:  []string{"s = \"abc\"", "s[0] + s[1:][0]"},
+			result: NewString("ab"),
+		},
+		{
+			exprs:  []string{"\"ell\" in \"hello\""},
+			result: NewBoolean(true),
+		},
+		{
+			exprs:  []string{"\"x\" in \"hello\" or false"},
+			result: NewBoolean(false),
+		},
+		{
+			exprs:  []string{"s = \"abc\"", "s[3]"},
+			result: nil,
+		},
+		{
+			exprs:  []string{"s = \"abc\"", "s[1.5]"},
+			result: nil,
+		},
+		{
+			exprs:  []string{"s = \"abc\"", "s[]"},
+			result: nil,
+		},
+		{
+			exprs:  []string{"s = \"abc\"", "s[1:2:3]"},
+			result: nil,
+		},
+		{
+			exprs:  []string{"\"a\" < 1"},
+			result: nil,
+		},
+		{
+			exprs:  []string{"1 in \"a\""},
+			result: nil,
+		},
+		{
+			exprs:  []string{"\"a\" in 1"},
+			result: nil,
+		},
+		{
+			exprs:  []string{"upper(\"héllo\")"},
+			result: NewString("HÉLLO"),
+		},
+		{
+			exprs:  []string{"lower(\"ÀB\") + upper(\"c\")"},
+			result: NewString("àbC"),
+		},
+		{
+			exprs:  []string{"len(\"héllo\")"},
+			result: NewInteger(5),
+		},
+		{
+			exprs:  []string{"len([1, 2, 3])"},
+			result: NewInteger(3),
+		},
+		{
+			exprs:  []string{"trim(\"  hi \") + trim(\"xxhixx\", \"x\")"},
+			result: NewString("hihi"),
+		},
+		{
+			exprs:  []string{"split(\"a,b,,c\", \",\")"},
+			result: NewList(NewString("a"), NewString("b"), NewString(""), NewString("c")),
+		},
+		{
+			exprs:  []string{"split(\" a  b \")"},
+			result: NewList(NewString("a"), NewString("b")),
+		},
+		{
+			exprs:  []string{"join([\"a\", 1, true], \"-\")"},
+			result: NewString("a-1-true"),
+		},
+		{
+			exprs:  []string{"join(split(\"a b\"))"},
+			result: NewString("ab"),
+		},
+		{
+			exprs:  []string{"replace(\"héhé\", \"é\", \"e\")"},
+			result: NewString("hehe"),
+		},
+		{
+			exprs:  []string{"contains(\"héllo\", \"él\") and startsWith(\"héllo\", \"hé\") and endsWith(\"héllo\", \"lo\")"},
+			result: NewBoolean(true),
+		},
+		{
+			exprs:  []string{"indexOf(\"héllo\", \"l\")"},
+			result: NewInteger(2),
+		},
+		{
+			exprs:  []string{"indexOf(\"héllo\", \"x\")"},
+			result: NewInteger(-1),
+		},
+		{
+			exprs:  []string{"repeat(\"é\", 1 + 2)"},
+			result: NewString("ééé"),
+		},
+		{
+			exprs:  []string{"reverse(\"héllo\")"},
+			result: NewString("olléh"),
+		},
+		{
+			exprs:  []string{"s = \"abc\"", "upper(s)[1:]"},
+			result: NewString("BC"),
+		},
+		{
+			exprs:  []string{"f = upper", "f(\"a\")"},
+			result: NewString("A"),
+		},
+		{
+			exprs:  []string{"len = 3", "len"},
+			result: NewInteger(3),
+		},
+		{
+			exprs:  []string{"[1, 1 + 1, \"x\"]"},
+			result: NewList(NewInteger(1), NewInteger(2), NewString("x")),
+		},
+		{
+			exprs:  []string{"[]"},
+			result: NewList(),
+		},
+		{
+			exprs:  []string{"2 in [1, 2, 3]"},
+			result: NewBoolean(true),
+		},
+		{
+			exprs:  []string{"[1, 2] + [3]"},
+			result: NewList(NewInteger(1), NewInteger(2), NewInteger(3)),
+		},
+		{
+			exprs:  []string{"upper(1)"},
+			result: nil,
+		},
+		{
+			exprs:  []string{"upper()"},
+			result: nil,
+		},
+		{
+			exprs:  []string{"upper(\"a\", \"b\")"},
+			result: nil,
+		},
+		{
+			exprs:  []string{"repeat(\"a\", 0 - 1)"},
+			result: nil,
+		},
+		{
+			exprs:  []string{"repeat(\"ab\", 9999999999)"},
+			result: nil,
+		},
+		{
+			exprs:  []string{"nothing(1)"},
+			result: nil,
+		},
+		{
+			exprs:  []string{"x = 1", "x(1)"},
+			result: nil,
+		},
+		{
+			exprs:  []string{"(1, 2)"},
+			result: nil,
+		},
+		{
+			exprs:  []string{"upper(\"a\",)"},
+			result: nil,
+		},
+		{
+			exprs:  []string{"null"},
+			result: NewNull(),
+		},
+		{
+			exprs:  []string{"nil == null"},
+			result: NewBoolean(true),
+		},
+		{
+			exprs:  []string{"null === nil"},
+			result: NewBoolean(true),
+		},
+		{
+			exprs:  []string{"x = null", "x"},
+			result: NewNull(),
+		},
+		{
+			exprs:  []string{"null == 0"},
+			result: NewBoolean(false),
+		},
+		{
+			exprs:  []string{"null != false"},
+			result: NewBoolean(true),
+		},
+		{
+			exprs:  []string{"null == \"\""},
+			result: NewBoolean(false),
+		},
+		{
+			exprs:  []string{"x = 1", "y = (x++)", "y == null"},
+			result: NewBoolean(true),
+		},
+		{
+			exprs:  []string{"x = 1", "y = (x = 2)", "y"},
+			result: NewNull(),
+		},
+		{
+			exprs:  []string{"[null, 1] == [nil, 1]"},
+			result: NewBoolean(true),
+		},
+		{
+			exprs:  []string{"null in [1, null]"},
+			result: NewBoolean(true),
+		},
+		{
+			exprs:  []string{"null + 1"},
+			result: nil,
+		},
+		{
+			exprs:  []string{"1 - nil"},
+			result: nil,
+		},
+		{
+			exprs:  []string{"-null"},
+			result: nil,
+		},
+		{
+			exprs:  []string{"!null"},
+			result: nil,
+		},
+		{
+			exprs:  []string{"null < 1"},
+			result: nil,
+		},
+		{
+			exprs:  []string{"null and true"},
+			result: nil,
+		},
+		{
+			exprs:  []string{"\"a\" + null"},
+			result: nil,
+		},
+		{
+			exprs:  []string{"1 in null"},
+			result: nil,
+		},
+		{
+			exprs:  []string{"x = null", "x++"},
+			result: nil,
+		},
+		{
+			exprs:  []string{"len(null)"},
+			result: nil,
+		},
+		{
+			exprs:  []string{"a ?? 1"},
+			result: NewInteger(1),
+		},
+		{
+			exprs:  []string{"a = null", "a ?? 2"},
+			result: NewInteger(2),
+		},
+		{
+			exprs:  []string{"a = 0", "a ?? 2"},
+			result: NewInteger(0),
+		},
+		{
+			exprs:  []string{"a ?? b ?? \"z\""},
+			result: NewString("z"),
+		},
+		{
+			exprs:  []string{"x = 1", "y = 1 ?? (x = 2)", "x"},
+			result: NewInteger(1),
+		},
+		{
+			exprs:  []string{"x = 1", "y = a ?? (x = 2)", "x"},
+			result: NewInteger(2),
+		},
+		{
+			exprs:  []string{"a ?? 1 + 2"},
+			result: NewInteger(3),
+		},
+		{
+			exprs:  []string{"1 ?? 2 == 2"},
+			result: NewInteger(1),
+		},
+		{
+			exprs:  []string{"u?[0]"},
+			result: NewNull(),
+		},
+		{
+			exprs:  []string{"u = null", "u?[1:]"},
+			result: NewNull(),
+		},
+		{
+			exprs:  []string{"u?.name"},
+			result: NewNull(),
+		},
+		{
+			exprs:  []string{"u?.name ?? \"anon\""},
+			result: NewString("anon"),
+		},
+		{
+			exprs:  []string{"l = [1, [2, 3]]", "l?[1]?[0]"},
+			result: NewInteger(2),
+		},
+		{
+			exprs:  []string{"s = \"abc\"", "s?[1:]"},
+			result: NewString("bc"),
+		},
+		{
+			exprs:  []string{"1 + a ?? 2"},
+			result: nil,
+		},
+		{
+			exprs:  []string{"a ?? 1 + b"},
+			result: nil,
+		},
+		{
+			exprs:  []string{"l = [1]", "l?[5]"},
+			result: nil,
+		},
+		{
+			exprs:  []string{"l = [1]", "l?.name"},
+			result: nil,
+		},
+		{
+			exprs:  []string{"u?.1"},
+			result: nil,
+		},
+		{
+			exprs:  []string{"?[1]"},
+			result: nil,
+		},
+		{
+			exprs:  []string{"true ? 1 : 2"},
+			result: NewInteger(1),
+		},
+		{
+			exprs:  []string{"x = 5", "x > 3 ? \"big\" : \"small\""},
+			result: NewString("big"),
+		},
+		{
+			exprs:  []string{"x = 5", "y = x < 3 ? 1 : 2", "y"},
+			result: NewInteger(2),
+		},
+		{
+			exprs:  []string{"x = 5", "x > 9 ? 1 : x > 4 ? 2 : 3"},
+			result: NewInteger(2),
+		},
+		{
+			exprs:  []string{"true ? false ? 1 : 2 : 3"},
+			result: NewInteger(2),
+		},
+		{
+			exprs:  []string{"1 + (false ? 2 : 3) * 2"},
+			result: NewInteger(7),
+		},
+		{
+			exprs:  []string{"s = \"hello\"", "s[true ? 1 : 0:3]"},
+			result: NewString("el"),
+		},
+		{
+			exprs:  []string{"(true ? [1] : [2])[0]"},
+			result: NewInteger(1),
+		},
+		{
+			exprs:  []string{"z = 0", "y = true ? 1 : (z = 5)", "z"},
+			result: NewInteger(0),
+		},
+		{
+			exprs:  []string{"a ?? 0 ? \"t\" : \"f\""},
+			result: NewString("f"),
+		},
+		{
+			exprs:  []string{"null ? 1 : 2"},
+			result: NewInteger(2),
+		},
+		{
+			exprs:  []string{"true ? 1"},
+			result: nil,
+		},
+		{
+			exprs:  []string{"true : 1"},
+			result: nil,
+		},
+		{
+			exprs:  []string{"? 1 : 2"},
+			result: nil,
+		},
+		{
+			exprs:  []string{"true ? 1 : undefined"},
+			result: NewInteger(1),
+		},
+		{
+			exprs:  []string{"false ? 1 : undefined"},
+			result: nil,
+		},
+		{
+			exprs:  []string{"match 3", "case 1", "\"a\"", "case 3", "\"b\"", "end"},
+			result: NewString("b"),
+		},
+		{
+			exprs:  []string{"match 2 case 1, 2 \"low\" case 3 \"high\" end"},
+			result: NewString("low"),
+		},
+		{
+			exprs:  []string{"match 9 case 1 \"a\" else \"z\" end"},
+			result: NewString("z"),
+		},
+		{
+			exprs:  []string{"match 9 case 1 \"a\" end"},
+			result: NewNull(),
+		},
+		{
+			exprs:  []string{"match \"s\" case int 1 case string 2 end"},
+			result: NewInteger(2),
+		},
+		{
+			exprs:  []string{"match 4.5 case 1..<4 \"low\" case 4..5 \"mid\" end"},
+			result: NewString("mid"),
+		},
+		{
+			exprs:  []string{"match 5 case 1..<5 \"low\" case 5..9 \"mid\" end"},
+			result: NewString("mid"),
+		},
+		{
+			exprs:  []string{"match -1 case -1 \"neg\" end"},
+			result: NewString("neg"),
+		},
+		{
+			exprs:  []string{"match null case null 1 case _ 2 end"},
+			result: NewInteger(1),
+		},
+		{
+			exprs:  []string{"match 1.0 case 1 \"int\" case 1.0 \"float\" end"},
+			result: NewString("float"),
+		},
+		{
+			exprs:  []string{"match [3, [4, 5]] case [_, [x, y]] x + y end"},
+			result: NewInteger(9),
+		},
+		{
+			exprs:  []string{"match [1, 2] case [a, b] if a > b \"desc\" case [a, b] \"asc\" end"},
+			result: NewString("asc"),
+		},
+		{
+			exprs:  []string{"match 7 case n if n > 5 n * 2 case n n end"},
+			result: NewInteger(14),
+		},
+		{
+			exprs:  []string{"match 7 case n if n > 9 if n > 5 1 else 2 end else 3 end"},
+			result: NewInteger(3),
+		},
+		{
+			exprs:  []string{"match 7 case n if n > 5 if n > 8 1 else 2 end else 3 end"},
+			result: NewInteger(2),
+		},
+		{
+			exprs:  []string{"x = 5", "match x % 3 case 0 \"fizz\" case 1, 2 x end"},
+			result: NewInteger(5),
+		},
+		{
+			exprs:  []string{"match 3 case 1 \"a\" case 1 \"b\" end"},
+			result: nil,
+		},
+		{
+			exprs:  []string{"match 3 case 1, 1 \"a\" end"},
+			result: nil,
+		},
+		{
+			exprs:  []string{"match 3 case _ \"a\" case 1 \"b\" end"},
+			result: nil,
+		},
+		{
+			exprs:  []string{"match 3 case int \"a\" case 2 \"b\" end"},
+			result: nil,
+		},
+		{
+			exprs:  []string{"match 7 case 1..10 by 2 \"odd\" else \"even\" end"},
+			result: NewString("odd"),
+		},
+		{
+			exprs:  []string{"match 8 case 1..10 by 2 \"odd\" case 0..<10 by 2 \"even\" end"},
+			result: NewString("even"),
+		},
+		{
+			exprs:  []string{"match 7.5 case 1..10 by 2 \"odd\" else \"other\" end"},
+			result: NewString("other"),
+		},
+		{
+			exprs:  []string{"match 1 case 10..1 by -3 \"down\" else \"other\" end"},
+			result: NewString("down"),
+		},
+		{
+			exprs:  []string{"match 3 case 1..9 by 2 \"a\" case 1..9 by 2 \"b\" end"},
+			result: nil,
+		},
+		{
+			exprs:  []string{"match 3 case 1..5 \"a\" case 3 \"b\" end"},
+			result: nil,
+		},
+		{
+			exprs:  []string{"match 3 case [a, _] \"a\" case [1, 2] \"b\" end"},
+			result: nil,
+		},
+		{
+			exprs:  []string{"match 3 case x \"a\" else \"b\" end"},
+			result: nil,
+		},
+		{
+			exprs:  []string{"match 3 case 1 + 2 \"a\" end"},
+			result: nil,
+		},
+		{
+			exprs:  []string{"match 3 \"a\" case 3 \"b\" end"},
+			result: nil,
+		},
+		{
+			exprs:  []string{"match 3 case 1 \"a\" else \"b\" case 3 \"c\" end"},
+			result: nil,
+		},
+		{
+			exprs:  []string{"1 case 2"},
+			result: nil,
+		},
+		{
+			exprs:  []string{"a, b = 1, 2", "a, b = b, a", "[a, b]"},
+			result: NewList(NewInteger(2), NewInteger(1)),
+		},
+		{
+			exprs:  []string{"a, b, c = 1, 2, 3", "a, b, c = c, a, b", "[a, b, c]"},
+			result: NewList(NewInteger(3), NewInteger(1), NewInteger(2)),
+		},
+		{
+			exprs:  []string{"x, y = [3, 4]", "x * 10 + y"},
+			result: NewInteger(34),
+		},
+		{
+			exprs:  []string{"pair = [\"a\", 1]", "k, v = pair", "k"},
+			result: NewString("a"),
+		},
+		{
+			exprs:  []string{"a, b = 1, 2", "m, n = a + 1, -b", "[m, n]"},
+			result: NewList(NewInteger(2), NewInteger(-2)),
+		},
+		{
+			exprs:  []string{"if true", "i, j = 7, 8", "end", "i + j"},
+			result: NewInteger(15),
+		},
+		{
+			exprs:  []string{"x, y = [1, 2]"},
+			result: NewNull(),
+		},
+		{
+			exprs:  []string{"p, q = 1, 2, 3"},
+			result: nil,
+		},
+		{
+			exprs:  []string{"p, q, r = 1, 2"},
+			result: nil,
+		},
+		{
+			exprs:  []string{"p = 1, 2"},
+			result: nil,
+		},
+		{
+			exprs:  []string{"p, q = [1]"},
+			result: nil,
+		},
+		{
+			exprs:  []string{"p, q = 5"},
+			result: nil,
+		},
+		{
+			exprs:  []string{"p, 1 = 1, 2"},
+			result: nil,
+		},
+		{
+			exprs:  []string{"a = 1", "a, b = 2, c", "a"},
+			result: NewInteger(1),
+		},
+		{
+			exprs:  []string{"type Person(name, age)", "{name, age} = Person(\"Ann\", 30)", "[name, age]"},
+			result: NewList(NewString("Ann"), NewInteger(30)),
+		},
+		{
+			exprs:  []string{"type Point(x, y)", "p = Point(1, 2)", "{y} = p", "y"},
+			result: NewInteger(2),
+		},
+		{
+			exprs:  []string{"class Vec", "x = 3", "end", "{x} = Vec()", "x"},
+			result: NewInteger(3),
+		},
+		{
+			exprs:  []string{"type Point(x, y)", "{x}, n = Point(4, 5), 6", "x + n"},
+			result: NewInteger(10),
+		},
+		{
+			exprs:  []string{"type Point(x, y)", "const {x, y} = Point(1, 2)", "x = 3"},
+			result: nil,
+		},
+		{
+			exprs:  []string{"type Point(x, y)", "x = 0", "{x, z} = Point(1, 2)"},
+			result: nil,
+		},
+		{
+			exprs:  []string{"type Point(x, y)", "x = 0", "try", "{x, z} = Point(1, 2)", "catch e", "end", "x"},
+			result: NewInteger(0),
+		},
+		{
+			exprs:  []string{"{x} = [1]"},
+			result: nil,
+		},
+		{
+			exprs:  []string{"{x, x} = 1"},
+			result: nil,
+		},
+		{
+			exprs:  []string{"{} = 1"},
+			result: nil,
+		},
+		{
+			exprs:  []string{"{x + 1} = 1"},
+			result: nil,
+		},
+		{
+			exprs:  []string{"{x}"},
+			result: nil,
+		},
+		{
+			exprs:  []string{"const MAX = 100", "MAX"},
+			result: NewInteger(100),
+		},
+		{
+			exprs:  []string{"const A, B = 1, 2", "A + B"},
+			result: NewInteger(3),
+		},
+		{
+			exprs:  []string{"x = 1", "const x = x + 1", "x"},
+			result: NewInteger(2),
+		},
+		{
+			exprs:  []string{"const MAX = 100", "MAX = 5"},
+			result: nil,
+		},
+		{
+			exprs:  []string{"const MAX = 100", "MAX++"},
+			result: nil,
+		},
+		{
+			exprs:  []string{"const A, B = 1, 2", "B, c = 3, 4"},
+			result: nil,
+		},
+		{
+			exprs:  []string{"const MAX = 1", "const MAX = 2"},
+			result: nil,
+		},
+		{
+			exprs:  []string{"const MAX = 1", "MAX = 2", "MAX"},
+			result: NewInteger(1),
+		},
+		{
+			exprs:  []string{"if true const K = 1 end", "K = 2"},
+			result: nil,
+		},
+		{
+			exprs:  []string{"if true", "const K = 1", "end", "match 2 case K 0 end"},
+			result: nil,
+		},
+		{
+			exprs:  []string{"const 5"},
+			result: nil,
+		},
+		{
+			exprs:  []string{"fn f() const K = 1 K end", "f()", "K = 5", "K"},
+			result: NewInteger(5),
+		},
+		{
+			exprs:  []string{"fn f()", "const K = 1", "K = 2", "end", "f()"},
+			result: nil,
+		},
+		{
+			exprs:  []string{"const G = 1", "fn f() G = 2 end"},
+			result: nil,
+		},
+		{
+			exprs:  []string{"fn f() x: int = 1 end", "f()", "x = \"s\"", "x"},
+			result: NewString("s"),
+		},
+		{
+			exprs:  []string{"fn f()", "x: int = 1", "x = \"s\"", "end", "f()"},
+			result: nil,
+		},
+		{
+			exprs:  []string{"x: int = 1", "fn f() x = \"s\" end", "f()"},
+			result: nil,
+		},
+		{
+			exprs:  []string{"const y"},
+			result: nil,
+		},
+		{
+			exprs:  []string{"x: int = 3", "x + 1"},
+			result: NewInteger(4),
+		},
+		{
+			exprs:  []string{"a: int, b: string = 1, \"b\"", "b * a"},
+			result: NewString("b"),
+		},
+		{
+			exprs:  []string{"z = 1", "z = \"free\"", "z"},
+			result: NewString("free"),
+		},
+		{
+			exprs:  []string{"x: int = 3", "x = \"s\""},
+			result: nil,
+		},
+		{
+			exprs:  []string{"x: int = 3", "x = \"s\"", "x"},
+			result: NewInteger(3),
+		},
+		{
+			exprs:  []string{"x: float = 3"},
+			result: nil,
+		},
+		{
+			exprs:  []string{"x: int = 3", "x: string = \"s\"", "x"},
+			result: NewString("s"),
+		},
+		{
+			exprs:  []string{"x: foo = 1"},
+			result: nil,
+		},
+		{
+			exprs:  []string{"1: int = 1"},
+			result: nil,
+		},
+		{
+			exprs:  []string{"const K: int = 2", "K * 2"},
+			result: NewInteger(4),
+		},
+		{
+			exprs:  []string{"\"abc\" - 1"},
+			result: nil,
+		},
+		{
+			exprs:  []string{"x = 0", "if false", "x = \"abc\" - 1", "end", "x"},
+			result: NewInteger(0),
+		},
+		{
+			exprs:  []string{"type Point(x, y)", "p = Point(1, 2)", "p.x + p.y"},
+			result: NewInteger(3),
+		},
+		{
+			exprs:  []string{"type Point(x, y)", "p = Point(1, 2)", "p.x = 5", "p.x"},
+			result: NewInteger(5),
+		},
+		{
+			exprs:  []string{"type Point(x, y)", "p = Point(1, 2)", "p.x, p.y = p.y, p.x", "p.x"},
+			result: NewInteger(2),
+		},
+		{
+			exprs:  []string{"type Point(x, y)", "p = Point(1, 2)", "p.y++", "p.y"},
+			result: NewInteger(3),
+		},
+		{
+			exprs:  []string{"type Point(x, y)", "Point(1, 2) == Point(1, 2)"},
+			result: NewBoolean(true),
+		},
+		{
+			exprs:  []string{"type Point(x, y)", "Point(1, 2) == Point(2, 1)"},
+			result: NewBoolean(false),
+		},
+		{
+			exprs:  []string{"type A(x)", "type B(x)", "A(1) == B(1)"},
+			result: NewBoolean(false),
+		},
+		{
+			exprs:  []string{"type Point(x, y)", "type Line(a, b)", "l = Line(Point(0, 0), Point(1, 2))", "l.b.y"},
+			result: NewInteger(2),
+		},
+		{
+			exprs:  []string{"type Point(x, y)", "[Point(1, 2)][0].y"},
+			result: NewInteger(2),
+		},
+		{
+			exprs:  []string{"type Point(x, y)", "p = null", "p?.x ?? 7"},
+			result: NewInteger(7),
+		},
+		{
+			exprs:  []string{"type Point(x, y)", "p = Point(1, 2)", "q = p", "q.x = 9", "p.x"},
+			result: NewInteger(9),
+		},
+		{
+			exprs:  []string{"type Point(x, y)", "Point(1, 2).z"},
+			result: nil,
+		},
+		{
+			exprs:  []string{"type Point(x, y)", "Point(1)"},
+			result: nil,
+		},
+		{
+			exprs:  []string{"type Point(x, x)"},
+			result: nil,
+		},
+		{
+			exprs:  []string{"type Point(1, y)"},
+			result: nil,
+		},
+		{
+			exprs:  []string{"type Point"},
+			result: nil,
+		},
+		{
+			exprs:  []string{"x = 1", "x.y = 2"},
+			result: nil,
+		},
+		{
+			exprs:  []string{".5 + 1"},
+			result: NewFloat(1.5),
+		},
+		{
+			exprs:  []string{"fn add(a, b)", "a + b", "end", "add(1, 2)"},
+			result: NewInteger(3),
+		},
+		{
+			exprs:  []string{"fn fact(n)", "if n <= 1 1 else n * fact(n - 1) end", "end", "fact(5)"},
+			result: NewInteger(120),
+		},
+		{
+			exprs:  []string{"fn add(a, b) a + b end", "add(1)"},
+			result: nil,
+		},
+		{
+			exprs:  []string{"fn f(a, a) a end"},
+			result: nil,
+		},
+		{
+			exprs:  []string{"fn f(a: string) -> bool a == \"x\" end", "f(\"x\")"},
+			result: NewBoolean(true),
+		},
+		{
+			exprs:  []string{"fn f(a: string) -> bool a == \"x\" end", "f(1)"},
+			result: nil,
+		},
+		{
+			exprs:  []string{"fn f(a: int, b) -> string b end", "f(1, 2)"},
+			result: nil,
+		},
+		{
+			exprs:  []string{"fn twice(n: int) -> int", "n * 2", "end", "twice(4)"},
+			result: NewInteger(8),
+		},
+		{
+			exprs:  []string{"fn f(n: int) n = 1.5 end"},
+			result: nil,
+		},
+		{
+			exprs:  []string{"fn f(a: nosuch) a end"},
+			result: nil,
+		},
+		{
+			exprs:  []string{"f(a) -> int"},
+			result: nil,
+		},
+		{
+			exprs:  []string{"x: string = \"s\"", "fn f(x: int) x + 1 end", "f(1)"},
+			result: NewInteger(2),
+		},
+		{
+			exprs:  []string{"\"ab\"[2^70]"},
+			result: nil,
+		},
+		{
+			exprs:  []string{"\"abc\"[1:2^70] + \"abc\"[-(2^70):1]"},
+			result: NewString("bca"),
+		},
+		{
+			exprs:  []string{"x: int = 1", "f = x => x + \"a\"", "f(\"b\")"},
+			result: NewString("ba"),
+		},
+		{
+			exprs:  []string{"fn loop(n) loop(n + 1) end", "loop(0)"},
+			result: nil,
+		},
+		{
+			exprs:  []string{"x = 1", "fn set() x = 2 end", "set()", "x"},
+			result: NewInteger(2),
+		},
+		{
+			exprs:  []string{"fn set() y = 2 end", "set()", "y"},
+			result: nil,
+		},
+		{
+			exprs:  []string{"x = 1", "fn get(x) x end", "get(5) + x"},
+			result: NewInteger(6),
+		},
+		{
+			exprs:  withAnimal("Animal(\"cat\").speak()"),
+			result: NewString("cat says ..."),
+		},
+		{
+			exprs: withAnimal("class Dog(Animal)", "sound = \"woof\"",
+				"fn speak() super.speak() + \"!\" end", "end",
+				"Dog(\"rex\").speak()"),
+			result: NewString("rex says woof!"),
+		},
+		{
+			exprs:  withAnimal("a = Animal(\"cat\")", "a.name = \"tom\"", "a.speak()"),
+			result: NewString("tom says ..."),
+		},
+		{
+			exprs:  withAnimal("a = Animal(\"cat\")", "a.age = 3"),
+			result: nil,
+		},
+		{
+			exprs:  withAnimal("Animal()"),
+			result: nil,
+		},
+		{
+			exprs:  withAnimal("a = Animal(\"cat\")", "a == a"),
+			result: NewBoolean(true),
+		},
+		{
+			exprs:  withAnimal("Animal(\"cat\") == Animal(\"cat\")"),
+			result: NewBoolean(false),
+		},
+		{
+			exprs:  []string{"class Counter", "n = 0", "fn inc()", "this.n++", "this", "end", "end", "Counter().inc().inc().n"},
+			result: NewInteger(2),
+		},
+		{
+			exprs:  []string{"class Point", "x = 0", "end", "Point(1)"},
+			result: nil,
+		},
+		{
+			exprs:  []string{"x = 1", "class Point(x)", "end"},
+			result: nil,
+		},
+		{
+			exprs:  []string{"class Point", "print 1", "end"},
+			result: nil,
+		},
+		{
+			exprs:  withVec("(Vec(1, 2) + Vec(3, 4)).y"),
+			result: NewInteger(6),
+		},
+		{
+			exprs:  withVec("(Vec(1, 2) * 3).x"),
+			result: NewInteger(3),
+		},
+		{
+			exprs:  withVec("(2 * Vec(1, 2)).y"),
+			result: NewInteger(4),
+		},
+		{
+			exprs:  withVec("Vec(1, 2) == Vec(1, 2)"),
+			result: NewBoolean(true),
+		},
+		{
+			exprs:  withVec("Vec(1, 2) != Vec(1, 2)"),
+			result: NewBoolean(false),
+		},
+		{
+			exprs:  withVec("(-Vec(1, 2)).x"),
+			result: NewInteger(-1),
+		},
+		{
+			exprs:  withVec("Vec(5, 0) > Vec(1, 2)"),
+			result: NewBoolean(true),
+		},
+		{
+			exprs:  withVec("2 in Vec(1, 2)"),
+			result: NewBoolean(true),
+		},
+		{
+			exprs:  withVec("Vec(1, 2) - Vec(1, 2)"),
+			result: nil,
+		},
+		{
+			exprs:  withVec("v = Vec(1, 2)", "w = v", "v = v + Vec(1, 1)", "w.x"),
+			result: NewInteger(1),
+		}, {
+			exprs:  []string{"double = (x) => x * 2", "double(4)"},
+			result: NewInteger(8),
+		},
+		{
+			exprs:  []string{"add = (a, b) => a + b", "add(2, 3)"},
+			result: NewInteger(5),
+		},
+		{
+			exprs:  []string{"inc = x => x + 1", "inc(1)"},
+			result: NewInteger(2),
+		},
+		{
+			exprs:  []string{"one = () => 1", "one()"},
+			result: NewInteger(1),
+		},
+		{
+			exprs:  []string{"((x) => x * 3)(3)"},
+			result: NewInteger(9),
+		},
+		{
+			exprs:  []string{"fs = [(x) => x + 1, (x) => x * 10]", "fs[1](5)"},
+			result: NewInteger(50),
+		},
+		{
+			exprs:  []string{"curry = (a) => (b) => a - b", "curry(10)(3)"},
+			result: NewInteger(7),
+		},
+		{
+			exprs:  []string{"sign = (x) => x < 0 ? -1 : 1", "sign(-5)"},
+			result: NewInteger(-1),
+		},
+		{
+			exprs:  []string{"fn adder(k) (x) => x + k end", "add5 = adder(5)", "add5(10)"},
+			result: NewInteger(15),
+		},
+		{
+			exprs: []string{"fn counter()", "n = 0", "fn next()", "n++", "n", "end", "next", "end",
+				"c = counter()", "c()", "d = counter()", "d()", "c()"},
+			result: NewInteger(2),
+		},
+		{
+			exprs: []string{"fn box()", "v = 1", "get = () => v", "set = (x) => v = x", "l = [get, set]", "l", "end",
+				"b = box()", "b[1](42)", "b[0]()"},
+			result: NewInteger(42),
+		},
+		{
+			exprs:  []string{"n = 1", "get = () => n", "n = 2", "get()"},
+			result: NewInteger(2),
+		},
+		{
+			exprs:  []string{"f: function = (x) => x", "f(3)"},
+			result: NewInteger(3),
+		},
+		{
+			exprs:  []string{"(a, a) => 1"},
+			result: nil,
+		},
+		{
+			exprs:  []string{"(1) => 1"},
+			result: nil,
+		},
+		{
+			exprs:  []string{"add = (a, b) => a + b", "add(1)"},
+			result: nil,
+		},
+		{
+			exprs:  []string{"x = 7", "x in 1..10"},
+			result: NewBoolean(true),
+		},
+		{
+			exprs:  []string{"10 in 1..<10"},
+			result: NewBoolean(false),
+		},
+		{
+			exprs:  []string{"4 in 1..10 by 2"},
+			result: NewBoolean(false),
+		},
+		{
+			exprs:  []string{"(1..10 by 2)[1]"},
+			result: NewInteger(3),
+		},
+		{
+			exprs:  []string{"(10..1 by -3)[-1]"},
+			result: NewInteger(1),
+		},
+		{
+			exprs:  []string{"s = \"hello\"", "s[1..3]"},
+			result: NewString("ell"),
+		},
+		{
+			exprs:  []string{"l = [1, 2, 3, 4]", "l[0..<2]"},
+			result: NewList(NewInteger(1), NewInteger(2)),
+		},
+		{
+			exprs:  []string{"total = 0", "for i in 1..100", "total = total + i", "end", "total"},
+			result: NewInteger(5050),
+		},
+		{
+			exprs:  []string{"r = 0", "for c in [1, 2, 3]", "r = r * 10 + c", "end", "r"},
+			result: NewInteger(123),
+		},
+		{
+			exprs:  []string{"len(1..<5)"},
+			result: NewInteger(4),
+		},
+		{
+			exprs:  []string{"len(5..1)"},
+			result: NewInteger(0),
+		},
+		{
+			exprs:  []string{"sum(1..1000000000 by 999999999)"},
+			result: NewInteger(1000000001),
+		},
+		{
+			exprs:  []string{"1..3 == 1..<4"},
+			result: NewBoolean(true),
+		},
+		{
+			exprs:  []string{"match 3 case 1..5 \"in\" else \"out\" end"},
+			result: NewString("in"),
+		},
+		{
+			exprs:  []string{"1..5 by 0"},
+			result: nil,
+		},
+		{
+			exprs:  []string{"len(1..9223372036854775807)"},
+			result: NewInteger(9223372036854775807),
+		},
+		{
+			exprs:  []string{"len(0..9223372036854775807)"},
+			result: nil,
+		},
+		{
+			exprs:  []string{"len(-9223372036854775807..9223372036854775807 by 2)"},
+			result: nil,
+		},
+		{
+			exprs:  []string{"0 in -9223372036854775807..9223372036854775807"},
+			result: NewBoolean(true),
+		},
+		{
+			exprs:  []string{"(-9223372036854775807..9223372036854775807)[-1]"},
+			result: NewInteger(9223372036854775807),
+		},
+		{
+			exprs:  []string{"(-9223372036854775807..9223372036854775807)[9223372036854775807]"},
+			result: NewInteger(0),
+		},
+		{
+			exprs:  []string{"0 in -9223372036854775807..9223372036854775807 by 2"},
+			result: NewBoolean(false),
+		},
+		{
+			exprs:  []string{"1 in -9223372036854775807..9223372036854775807 by 2"},
+			result: NewBoolean(true),
+		},
+		{
+			exprs:  []string{"(-9223372036854775807..9223372036854775807 by 2)[-1]"},
+			result: NewInteger(9223372036854775807),
+		},
+		{
+			exprs:  []string{"min = -9223372036854775807", "min = min - 1", "len(min..<-1)"},
+			result: NewInteger(9223372036854775807),
+		},
+		{
+			exprs:  []string{"min = -9223372036854775807", "min = min - 1", "min in min..<-1"},
+			result: NewBoolean(true),
+		},
+		{
+			exprs:  []string{"len(9223372036854775807..<9223372036854775807)"},
+			result: NewInteger(0),
+		},
+		{
+			exprs:  []string{"9223372036854775807 in 0..<9223372036854775807"},
+			result: NewBoolean(false),
+		},
+		{
+			exprs:  []string{"len(9223372036854775807..-9223372036854775807 by -9223372036854775807)"},
+			result: NewInteger(3),
+		},
+		{
+			exprs:  []string{"for x in 5", "x", "end"},
+			result: nil,
+		},
+		{
+			exprs:  withGenerators("l = []", "for x in count(3)", "l = l + [x]", "end", "l"),
+			result: NewList(NewInteger(0), NewInteger(1), NewInteger(2)),
+		},
+		{
+			exprs:  withGenerators("g = count(4)", "sum(g) + sum(g)"),
+			result: NewInteger(12),
+		},
+		{
+			exprs:  withGenerators("map(count(3), (x) => x * 10)"),
+			result: NewList(NewInteger(0), NewInteger(10), NewInteger(20)),
+		},
+		{
+			exprs: []string{"fn naturals()", "i = 0", "for true", "yield i", "i++", "end", "end",
+				"t = 0", "for n in naturals()", "if n > 4", "break", "end", "t = t + n", "end", "t"},
+			result: NewInteger(10),
+		},
+		{
+			exprs: withGenerators("fn evens(g)", "for x in g", "if x % 2 == 0", "yield x", "end", "end", "end",
+				"reduce(evens(count(7)), (a, b) => a + b)"),
+			result: NewInteger(12),
+		},
+		{
+			exprs:  []string{"fn pair(a, b)", "yield a", "yield b", "end", "zip(pair(1, 2), pair(3, 4))"},
+			result: NewList(NewList(NewInteger(1), NewInteger(3)), NewList(NewInteger(2), NewInteger(4))),
+		},
+		{
+			exprs:  []string{"i = 0", "for true", "i++", "if i == 5", "break", "end", "end", "i"},
+			result: NewInteger(5),
+		},
+		{
+			exprs:  []string{"n = 0", "for x in 1..10", "match x", "case 3", "break", "end", "n = x", "end", "n"},
+			result: NewInteger(2),
+		},
+		{
+			exprs:  []string{"break"},
+			result: nil,
+		},
+		{
+			exprs:  []string{"fn stop() break end", "for x in 1..3", "stop()", "end"},
+			result: nil,
+		},
+		{
+			exprs:  []string{"yield 1"},
+			result: nil,
+		},
+		{
+			exprs:  []string{"fn bad()", "yield 1", "yield 1 + \"a\" - 1", "end", "sum(bad())"},
+			result: nil,
+		},
+		{
+			exprs:  []string{"try", "1 / 0", "catch e", "e.kind", "end"},
+			result: NewString("ZeroDivisionError"),
+		},
+		{
+			exprs:  []string{"try", "x = 1", "x = x / 0", "catch e", "l = [e.line, e.column]", "end", "l"},
+			result: NewList(NewInteger(3), NewInteger(7)),
+		},
+		{
+			exprs:  []string{"try", "l = [1]", "l[5]", "catch e", "l = [e.line, e.column]", "end", "l"},
+			result: NewList(NewInteger(3), NewInteger(2)),
+		},
+		{
+			exprs:  []string{"try", "null.x", "catch e", "l = [e.line, e.column]", "end", "l"},
+			result: NewList(NewInteger(2), NewInteger(5)),
+		},
+		{
+			exprs:  []string{"try", "[1][5]", "catch e", "e.kind", "end"},
+			result: NewString("IndexError"),
+		},
+		{
+			exprs:  []string{"try", "null.x", "catch e", "e.kind", "end"},
+			result: NewString("TypeError"),
+		},
+		{
+			exprs:  []string{"try", "x = 3", "x - \"a\"", "catch e", "e.message", "end"},
+			result: NewString("operator \"-\" can't be used on int and string"),
+		},
+		{
+			exprs:  []string{"try", "missing + 1", "catch e", "e.kind", "end"},
+			result: NewString("NameError"),
+		},
+		{
+			exprs:  []string{"try", "\"a\" - 1", "catch e", "e.kind", "end"},
+			result: NewString("TypeError"),
+		},
+		{
+			exprs:  []string{"try", "throw 1", "catch e", "end", "e"},
+			result: nil,
+		},
+		{
+			exprs:  []string{"e = 5", "try", "throw 1", "catch e", "e.message", "end", "e"},
+			result: NewInteger(5),
+		},
+		{
+			exprs:  []string{"try", "throw 1", "catch e", "const e = 3", "end", "e = 5", "e"},
+			result: NewInteger(5),
+		},
+		{
+			exprs:  []string{"x: int = 1", "try", "throw \"boom\"", "catch x", "x.message", "end"},
+			result: NewString("boom"),
+		},
+		{
+			exprs:  []string{"try", "throw \"boom\"", "catch e", "msg = e.message", "end", "msg"},
+			result: NewString("boom"),
+		},
+		{
+			exprs:  []string{"try", "throw \"oops\"", "catch e", "l = [e.kind, e.message]", "end", "l"},
+			result: NewList(NewString("Error"), NewString("oops")),
+		},
+		{
+			exprs:  []string{"try", "throw error(\"bad\", \"ValueError\")", "catch e", "e.kind", "end"},
+			result: NewString("ValueError"),
+		},
+		{
+			exprs:  []string{"try", "5", "catch e", "0", "end"},
+			result: NewInteger(5),
+		},
+		{
+			exprs:  []string{"n = 0", "try", "throw 1", "catch e", "n = 1", "finally", "n = n + 10", "end", "n"},
+			result: NewInteger(11),
+		},
+		{
+			exprs:  []string{"n = 0", "try", "n = 1", "finally", "n = n + 10", "end", "n"},
+			result: NewInteger(11),
+		},
+		{
+			exprs: []string{"fn check(n)", "if n < 0", "throw error(\"negative\", \"ValueError\")", "end", "n", "end",
+				"try", "check(-1)", "catch e", "e.message", "end"},
+			result: NewString("negative"),
+		},
+		{
+			exprs:  []string{"try", "try", "throw \"inner\"", "finally", "1", "end", "catch e", "e.message", "end"},
+			result: NewString("inner"),
+		},
+		{
+			exprs:  []string{"try", "throw \"a\"", "catch e", "throw e.message + \"b\"", "end"},
+			result: nil,
+		},
+		{
+			exprs:  []string{"n = 0", "for i in 1..5", "try", "break", "catch e", "n = 100", "finally", "n = n + i", "end", "end", "n"},
+			result: NewInteger(1),
+		},
+		{
+			exprs:  []string{"fn g()", "try", "yield 1", "yield 2", "finally", "yield 3", "end", "end", "sum(g())"},
+			result: NewInteger(6),
+		},
+		{
+			exprs:  []string{"throw \"uncaught\""},
+			result: nil,
+		},
+		{
+			exprs:  []string{"try", "throw 1", "finally", "2", "end"},
+			result: nil,
+		},
+		{
+			exprs:  []string{"try", "1", "end"},
+			result: nil,
+		},
+		{
+			exprs:  []string{"try", "1", "catch", "end"},
+			result: nil,
+		},
+	}
+
+	for _, tc := range cases {
+		var retrying bool
+
+	retryLoop:
+		for {
+			var failed bool
+
+			ctx := New()
+
+			exprs := strings.Join(tc.exprs, "\n")
+			res, err := ctx.EvalLine(tc.exprs...)
+			ctx.Close()
+
+			switch {
+			case err != nil && tc.result == nil:
+				t.Logf("PASS: %q: failed as expected: %s", exprs, err)
+			case err == nil && tc.result == nil:
+				failed = true
+				t.Errorf("ERROR: %q: should have failed, got %q instead", exprs, res)
+			case err != nil && tc.result != nil:
+				failed = true
+				t.Errorf("ERROR: %q: was expected to return %q: %s", exprs, tc.result, err)
+			case tc.result.Equal(res):
+				t.Logf("PASS: %q → %q", exprs, res)
+			default:
+				failed = true
+				t.Errorf("ERROR: %q: got %q expected %q", exprs, res, tc.result)
+			}
+
+			switch {
+			case !failed:
+				// PASS
+				debugStage = false
+				retrying = false
+				break retryLoop
+			case retrying:
+				// not again
+				debugStage = false
+				break retryLoop
+			default:
+				// oops, again but with logs
+				debugStage = true
+				retrying = true
+			}
+		}
+	}
+}
+
+func TestStrictIntegers(t *testing.T) {
+	cases := [][]string{
+		{"9223372036854775807 + 1"},
+		{"2 ^ 64"},
+		{"x = 9223372036854775807", "x++"},
+		{"100000000000000000000"},
+	}
+
+	for _, exprs := range cases {
+		ctx := New()
+		ctx.StrictIntegers = true
+
+		expr := strings.Join(exprs, "\n")
+		res, err := ctx.EvalLine(exprs...)
+		ctx.Close()
+		switch {
+		case errors.Is(err, errOverflow):
+			t.Logf("PASS: %q: failed as expected: %s", expr, err)
+		case err != nil:
+			t.Errorf("ERROR: %q: unexpected error: %s", expr, err)
+		default:
+			t.Errorf("ERROR: %q: should have failed, got %q instead", expr, res)
+		}
+	}
+}
+
+func TestDecimalRounding(t *testing.T) {
+	type testCase struct {
+		context DecimalContext
+		expr    string
+		result  string
+	}
+
+	var cases = []testCase{
+		{DefaultDecimalContext, "12.50d", "12.50"},
+		{DefaultDecimalContext, "-0.05d", "-0.05"},
+		{DefaultDecimalContext, "10.00d / 4", "2.50"},
+		{DefaultDecimalContext, "1d / 3", "0.3333333333333333"},
+		{DefaultDecimalContext, "2d / 3", "0.6666666666666667"},
+		{DecimalContext{2, RoundHalfEven}, "1.125d * 1", "1.12"},
+		{DecimalContext{2, RoundHalfEven}, "1.135d * 1", "1.14"},
+		{DecimalContext{2, RoundHalfUp}, "1.125d * 1", "1.13"},
+		{DecimalContext{2, RoundHalfDown}, "1.125d * 1", "1.12"},
+		{DecimalContext{2, RoundUp}, "1.121d * 1", "1.13"},
+		{DecimalContext{2, RoundDown}, "1.129d * 1", "1.12"},
+		{DecimalContext{0, RoundCeiling}, "0 - 2.5d", "-2"},
+		{DecimalContext{0, RoundFloor}, "0 - 2.5d", "-3"},
+		{DecimalContext{2, RoundHalfUp}, "19.99d * 0.15d", "3.00"},
+		{DecimalContext{2, RoundHalfUp}, "100d / 3", "33.33"},
+	}
+
+	for _, tc := range cases {
+		ctx := New()
+		ctx.Decimal = tc.context
+
+		res, err := ctx.EvalLine(tc.expr)
+		ctx.Close()
+		switch {
+		case err != nil:
+			t.Errorf("ERROR: %q (%v %v): %s", tc.expr, tc.context.Precision, tc.context.Rounding, err)
+		case res == nil || res.AsString() != tc.result:
+			t.Errorf("ERROR: %q (%v %v): got %v expected %q", tc.expr, tc.context.Precision, tc.context.Rounding, res, tc.result)
+		default:
+			t.Logf("PASS: %q (%v %v) → %q", tc.expr, tc.context.Precision, tc.context.Rounding, res)
+		}
+	}
+}
+
+func TestMalformedNumberPosition(t *testing.T) {
+	type testCase struct {
+		exprs []string
+		value string
+		pos   Position
+	}
+
+	var cases = []testCase{
+		{[]string{"1.2.3"}, "1.2.3", Position{1, 1}},
+		{[]string{"x = 1", "y = 0x"}, "0x", Position{2, 5}},
+		{[]string{"", "  z = 12abc + 1"}, "12abc", Position{2, 7}},
+	}
+
+	for _, tc := range cases {
+		var e *ErrInvalidToken
+
+		ctx := New()
+		_, err := ctx.EvalLine(tc.exprs...)
+		ctx.Close()
+		switch {
+		case !errors.As(err, &e):
+			t.Errorf("ERROR: %q: expected ErrInvalidToken, got %v", tc.exprs, err)
+		case e.Token.Value != tc.value || e.Token.Pos != tc.pos:
+			t.Errorf("ERROR: %q: got %q at %s, expected %q at %s",
+				tc.exprs, e.Token.Value, e.Token.Pos, tc.value, tc.pos)
+		default:
+			t.Logf("PASS: %q: %s", tc.exprs, err)
+		}
+	}
+}
+
+func TestSetConst(t *testing.T) {
+	ctx := New()
+	defer ctx.Close()
+	if err := ctx.SetConst("LIMIT", NewInteger(10)); err != nil {
+		t.Fatalf("ERROR: SetConst: %s", err)
+	}
+
+	if res, err := ctx.EvalLine("LIMIT * 2"); err != nil || !NewInteger(20).Equal(res) {
+		t.Errorf("ERROR: LIMIT * 2: got %v, %v", res, err)
+	}
+
+	for _, expr := range []string{"LIMIT = 1", "LIMIT++", "LIMIT, x = 1, 2", "const LIMIT = 1"} {
+		if _, err := ctx.EvalLine(expr); !errors.Is(err, errConstant) {
+			t.Errorf("ERROR: %q: expected %q, got %v", expr, errConstant, err)
+		}
+	}
+
+	if res, _ := ctx.EvalLine("LIMIT"); !NewInteger(10).Equal(res) {
+		t.Errorf("ERROR: LIMIT was overwritten with %v", res)
+	}
+
+	for _, name := range []string{"", "2x", "if", "true", "max-size"} {
+		if err := ctx.SetConst(name, NewNull()); err == nil {
+			t.Errorf("ERROR: SetConst(%q) should have failed", name)
+		}
+	}
+}
+
+func TestTypeMismatch(t *testing.T) {
+	type testCase struct {
+		exprs []string
+		pos   Position
+	}
+
+	var cases = []testCase{
+		{[]string{"\"abc\" - 1"}, Position{1, 7}},
+		{[]string{"x: int = 3", "y = -x + !x"}, Position{2, 10}},
+		{[]string{"s: string = \"a\"", "n: int = 1", "if n > 0", "  print s - n", "end"}, Position{4, 11}},
+		{[]string{"x: int = 3", "x = 1.5"}, Position{2, 3}},
+		{[]string{"a, b: bool = 1, 2"}, Position{1, 12}},
+		{[]string{"[1] + 1"}, Position{1, 5}},
+		{[]string{"1.5 * 2d"}, Position{1, 5}},
+		{[]string{"fn f(a: string) a - 1 end"}, Position{1, 19}},
+		{[]string{"fn f() -> int \"a\" end"}, Position{1, 1}},
+		{[]string{"try \"a\" - 1 finally 1 end"}, Position{1, 9}},
+		{[]string{"f = (x) => \"a\" - 1"}, Position{1, 16}},
+		{[]string{"class A fn m() \"a\" - 1 end end"}, Position{1, 20}},
+		{[]string{"class A", "fn m(n: int) -> string n end", "end"}, Position{2, 1}},
+		{[]string{"s: string = \"a\"", "f = x => s - 1"}, Position{2, 12}},
+	}
+
+	for _, tc := range cases {
+		var e *ErrTypeMismatch
+
+		ctx := New()
+		_, err := ctx.EvalLine(tc.exprs...)
+		ctx.Close()
+		switch {
+		case !errors.As(err, &e):
+			t.Errorf("ERROR: %q: expected ErrTypeMismatch, got %v", tc.exprs, err)
+		case e.Pos != tc.pos:
+			t.Errorf("ERROR: %q: reported at %s, expected %s", tc.exprs, e.Pos, tc.pos)
+		default:
+			t.Logf("PASS: %q: %s", tc.exprs, err)
+		}
+	}
+
+	// the checked statement didn't run
+	ctx := New()
+	defer ctx.Close()
+	res, err := ctx.EvalLine("x = 1", "if x > 0", "x = 2", "print \"a\" - 1", "end", "x")
+	if err != nil || !NewInteger(1).Equal(res) {
+		t.Errorf("ERROR: statement with a type mismatch ran: got %v, %v", res, err)
+	}
+}
+
+func TestEqualSymmetry(t *testing.T) {
+	values := []Value{
+		NewInteger(1),
+		NewInteger(0),
+		NewFloat(1),
+		NewFloat(1.5),
+		newBigInteger("18446744073709551616"),
+		NewFloat(18446744073709551616),
+		newDecimal("1.0"),
+		newDecimal("1.5"),
+		newDecimal("0.1"),
+		NewFloat(0.1),
+		NewString("1"),
+		NewString("1.5"),
+		NewString("0.1"),
+		NewString("true"),
+		NewString("abc"),
+		NewString(""),
+		NewBoolean(true),
+		NewBoolean(false),
+	}
+
+	// a string compared to a decimal is read as a decimal
+	if !looseEqual(NewString("0.1"), newDecimal("0.1")) {
+		t.Errorf("ERROR: \"0.1\" == 0.1d is false")
+	}
+
+	for _, a := range values {
+		for _, b := range values {
+			if looseEqual(a, b) != looseEqual(b, a) {
+				t.Errorf("ERROR: %#v == %#v isn't symmetric", a, b)
+			}
+			if strictEqual(a, b) != strictEqual(b, a) {
+				t.Errorf("ERROR: %#v === %#v isn't symmetric", a, b)
+			}
+			if a.Equal(b) != b.Equal(a) {
+				t.Errorf("ERROR: %#v.Equal(%#v) isn't symmetric", a, b)
+			}
+		}
+	}
+}
+
+// withGenerators prepends count(n), a generator of the
+// integers below n, and nat(), an endless one of the
+// natural numbers
+func withGenerators(exprs ...string) []string {
+	return append([]string{
+		"fn count(n)", "i = 0", "for i < n", "yield i", "i++", "end", "end",
+		"fn nat()", "i = 0", "for true", "yield i", "i++", "end", "end",
+	}, exprs...)
+}
+
+func TestGeneratorClose(t *testing.T) {
+	ctx := New()
+	defer ctx.Close()
+	_, err := ctx.EvalLine("fn ones()", "for true", "yield 1", "end", "end")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	before := runtime.NumGoroutine()
+	for i := 0; i < 10; i++ {
+		if _, err := ctx.EvalLine("for x in ones()", "break", "end"); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := ctx.EvalLine("zip([1], ones())", "any(ones())"); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// closed generators finish soon after the loop
+	for i := 0; i < 100 && runtime.NumGoroutine() > before; i++ {
+		time.Sleep(time.Millisecond)
+	}
+	if n := runtime.NumGoroutine(); n > before {
+		t.Errorf("%v goroutines left running", n-before)
+	}
+}
+
+func TestRecordString(t *testing.T) {
+	point := NewRecordType("Point", "x", "y")
+	p, err := point.CallValue(nil, NewInteger(1), NewString("a"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if s, expected := fmt.Sprint(p), `Point(x: 1, y: "a")`; s != expected {
+		t.Errorf("got %q expected %q", s, expected)
+	}
+	if s, expected := fmt.Sprint(point), "Point(x, y)"; s != expected {
+		t.Errorf("got %q expected %q", s, expected)
+	}
+}
+
+func TestObjectString(t *testing.T) {
+	ctx := New()
+	defer ctx.Close()
+	_, err := ctx.EvalLine("class Point", "x = 1", "y = \"a\"", "end", "p = Point()")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	p, _ := ctx.GetValue("p")
+	if s, expected := p.AsString(), `Point(x: 1, y: "a")`; s != expected {
+		t.Errorf("got %q expected %q", s, expected)
+	}
+}
+
+func TestRuntimeErrorTrace(t *testing.T) {
+	ctx := New()
+	defer ctx.Close()
+	_, err := ctx.EvalLine(
+		"fn half(x)",
+		"if x > 1",
+		"x / 0",
+		"end",
+		"end",
+		"for i in 1..3",
+		"half(i)",
+		"end",
+	)
+
+	var re *RuntimeError
+	if !errors.As(err, &re) {
+		t.Fatalf("expected a RuntimeError, got %#v", err)
+	}
+	if !errors.Is(err, errDivZero) {
+		t.Errorf("expected division by zero, got %s", err)
+	}
+	if expected := (Position{3, 3}); re.Pos != expected {
+		t.Errorf("got position %s expected %s", re.Pos, expected)
+	}
+
+	expected := []Frame{
+		{"if", Position{2, 1}},
+		{"call half", Position{7, 5}},
+		{"for i in", Position{6, 1}},
+	}
+	if len(re.Trace) != len(expected) {
+		t.Fatalf("got trace %v expected %v", re.Trace, expected)
+	}
+	for i, f := range expected {
+		if re.Trace[i] != f {
+			t.Errorf("frame %v: got %s expected %s", i, re.Trace[i], f)
+		}
+	}
+
+	if s := re.StackTrace(); !strings.HasPrefix(s, "division by zero at 3:3\n  in if at 2:1") {
+		t.Errorf("unexpected stack trace %q", s)
+	}
+}
+
+func TestErrorTypes(t *testing.T) {
+	type testCase struct {
+		exprs  []string
+		target error
+		code   ErrorCode
+		pos    Position
+	}
+
+	var cases = []testCase{
+		{[]string{"1 +* 2"}, &SyntaxError{}, CodeSyntax, Position{1, 3}},
+		{[]string{"x = 1.2.3"}, &SyntaxError{}, CodeSyntax, Position{1, 5}},
+		{[]string{"x = @"}, &SyntaxError{}, CodeSyntax, Position{1, 5}},
+		{[]string{"for a, b end"}, &SyntaxError{}, CodeSyntax, Position{1, 6}},
+		{[]string{"if 1, 2 end"}, &SyntaxError{}, CodeSyntax, Position{1, 5}},
+		{[]string{"=> 1"}, &SyntaxError{}, CodeSyntax, Position{1, 1}},
+		{[]string{"x = 1", ".. 1"}, &SyntaxError{}, CodeSyntax, Position{2, 1}},
+		{[]string{"..<5"}, &SyntaxError{}, CodeSyntax, Position{1, 1}},
+		{[]string{": 3"}, &SyntaxError{}, CodeSyntax, Position{1, 1}},
+		{[]string{"\"abc\" - 1"}, &TypeError{}, CodeType, Position{1, 7}},
+		{[]string{"x = \"abc\"", "x - 1"}, &TypeError{}, CodeType, Position{2, 3}},
+		{[]string{"len(1, 2)"}, &TypeError{}, CodeType, Position{1, 4}},
+		{[]string{"fn f(a: int) a end", "f(\"a\")"}, &TypeError{}, CodeType, Position{2, 2}},
+		{[]string{"y = missing + 1"}, &NameError{}, CodeName, Position{1, 5}},
+		{[]string{"x = 0", "1 / x"}, &ZeroDivisionError{}, CodeZeroDivision, Position{2, 3}},
+		{[]string{"x = 0", "if true", "  5 % x", "end"}, &ZeroDivisionError{}, CodeZeroDivision, Position{3, 5}},
+		{[]string{"fn r(n) r(n + 1) end", "r(0)"}, &LimitError{}, CodeLimit, Position{}},
+		{[]string{"x = 1 << 100000000000"}, &LimitError{}, CodeLimit, Position{1, 7}},
+		{[]string{"x = \"a\" * 9999999999"}, &LimitError{}, CodeLimit, Position{1, 9}},
+		{[]string{"x = 2 ^ 100000000"}, &LimitError{}, CodeLimit, Position{1, 7}},
+		{[]string{"x = 1..2^70"}, &LimitError{}, CodeLimit, Position{1, 6}},
+		{[]string{"x = 1..10 by 2^70"}, &LimitError{}, CodeLimit, Position{1, 11}},
+		{[]string{"[1][5]"}, &IndexError{}, CodeIndex, Position{1, 4}},
+		{[]string{"s = \"ab\"", "s[1:\"x\"]"}, &TypeError{}, CodeType, Position{2, 2}},
+		{[]string{"null.x"}, &TypeError{}, CodeType, Position{1, 5}},
+		{[]string{"x = 5", "{x} = x"}, &TypeError{}, CodeType, Position{2, 1}},
+		{[]string{"type P(a)", "{b} = P(1)"}, nil, CodeRuntime, Position{2, 1}},
+		{[]string{"class A end", "obj = A()", "obj.nosuch()"}, nil, CodeRuntime, Position{3, 4}},
+		{[]string{"x = 1", "x()"}, &TypeError{}, CodeType, Position{2, 2}},
+		{[]string{"try", "1 / 0", "catch e", "throw e", "end"}, &ZeroDivisionError{}, CodeZeroDivision, Position{2, 3}},
+		{[]string{"throw \"oops\""}, nil, CodeThrown, Position{1, 1}},
+	}
+
+	for _, tc := range cases {
+		var e Error
+
+		ctx := New()
+		_, err := ctx.EvalLine(tc.exprs...)
+		ctx.Close()
+		switch {
+		case !errors.As(err, &e):
+			t.Errorf("ERROR: %q: expected an Error, got %#v", tc.exprs, err)
+		case tc.target != nil && !errors.Is(err, tc.target):
+			t.Errorf("ERROR: %q: expected %T, got %#v", tc.exprs, tc.target, err)
+		case e.Code() != tc.code:
+			t.Errorf("ERROR: %q: got code %q expected %q", tc.exprs, e.Code(), tc.code)
+		case tc.pos.IsValid() && e.Position() != tc.pos:
+			t.Errorf("ERROR: %q: reported at %s, expected %s", tc.exprs, e.Position(), tc.pos)
+		default:
+			t.Logf("PASS: %q: %s %s", tc.exprs, e.Code(), err)
+		}
+	}
+
+	// the errors of the interpreter are still there
+	ctx := New()
+	defer ctx.Close()
+	_, err := ctx.EvalLine("1 / 0")
+	if !errors.Is(err, errDivZero) {
+		t.Errorf("ERROR: expected division by zero, got %#v", err)
+	}
+	var zde *ZeroDivisionError
+	if !errors.As(err, &zde) || zde.Pos != (Position{1, 3}) {
+		t.Errorf("ERROR: expected a ZeroDivisionError at 1:3, got %#v", err)
+	}
+}
+
+func TestInterrupt(t *testing.T) {
+	ctx := New()
+	defer ctx.Close()
+
+	go func() {
+		time.Sleep(200 * time.Millisecond)
+		ctx.Interrupt()
+	}()
+
+	_, err := ctx.EvalLine("try", "for true 1 end", "catch e", "end")
+	if !errors.Is(err, &LimitError{}) || !errors.Is(err, errInterrupted) {
+		t.Errorf("ERROR: expected an interrupted LimitError, got %#v", err)
+	}
+
+	// the statements after the interrupted one don't run
+	go func() {
+		time.Sleep(200 * time.Millisecond)
+		ctx.Interrupt()
+	}()
+
+	done := make(chan error)
+	go func() {
+		_, err := ctx.EvalLine("for true 1 end", "for true 1 end")
+		done <- err
+	}()
+
+	select {
+	case err := <-done:
+		if !errors.Is(err, errInterrupted) {
+			t.Errorf("ERROR: expected the second loop to be interrupted, got %#v", err)
+		}
+	case <-time.After(3 * time.Second):
+		t.Fatal("ERROR: the second loop kept running after Interrupt")
+	}
+
+	// the next call runs as usual
+	if v, err := ctx.EvalLine("1 + 1"); err != nil || !v.Equal(NewInteger(2)) {
+		t.Errorf("ERROR: got %v, %v after interrupting", v, err)
+	}
+}
+
+func TestClose(t *testing.T) {
+	before := runtime.NumGoroutine()
+	for i := 0; i < 10; i++ {
+		ctx := New()
+		if _, err := ctx.EvalLine("1 + 1"); err != nil {
+			t.Fatal(err)
+		}
+		ctx.Close()
+
+		if _, err := ctx.EvalLine("1 + 1"); err != io.EOF {
+			t.Fatalf("ERROR: expected io.EOF after Close, got %v", err)
+		}
+	}
+
+	for i := 0; i < 100 && runtime.NumGoroutine() > before; i++ {
+		time.Sleep(time.Millisecond)
+	}
+	if n := runtime.NumGoroutine(); n > before {
+		t.Errorf("%v goroutines left running", n-before)
+	}
+}
+
+func TestPanicRecovered(t *testing.T) {
+	ctx := New()
+	defer ctx.Close()
+
+	ctx.Variable["boom"] = &BuiltinFunction{
+		Name: "boom",
+		Handler: func(_ *Javalanche, _ BuiltinArgs) (Value, error) {
+			panic("boom")
+		},
+	}
+
+	_, err := ctx.EvalLine("fn f() boom() end", "f()")
+	if !errors.Is(err, errPanic) {
+		t.Errorf("ERROR: expected an internal error, got %#v", err)
+	}
+	if v, err := ctx.EvalLine("1 + 1"); err != nil || !v.Equal(NewInteger(2)) {
+		t.Errorf("ERROR: got %v, %v after a panic", v, err)
+	}
+}
+
+func FuzzEvalLine(f *testing.F) {
+	seeds := []string{
+		"1 + 2 * 3",
+		"x = 1.2.3",
+		"@",
+		"for end",
+		"for a, b end",
+		"if 1, 2 end",
+		"if true 1 elif false 2 else 3 end",
+		"fn f(a, b) a + b end",
+		"f(1, 2",
+		"[1, 2][5]",
+		"match 1 case 1 2 else 3 end",
+		"try throw \"x\" catch e e.message finally 1 end",
+		"for x in 1..5 break end",
+		"\"abc\" - 1",
+		"end end end",
+		"for true 1 end",
+		"fn f() f() end\nf()",
+		"sum(1..1000000000000)",
+	}
+	for _, s := range seeds {
+		f.Add(s)
+	}
+
+	f.Fuzz(func(t *testing.T, line string) {
+		// a short parser timeout keeps runs fast, and closing
+		// the interpreter lets its goroutines finish
+		ctx := newJavalanche(5 * time.Millisecond)
+		defer ctx.Close()
+
+		var err error
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			_, err = ctx.EvalLine(line)
+		}()
+
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			// scripts may not end, but they need to stop
+			ctx.Close()
+
+			select {
+			case <-done:
+			case <-time.After(5 * time.Second):
+				t.Fatalf("EvalLine(%q) didn't stop after Close", line)
+			}
+		}
+
+		// recovered panics are still bugs
+		if errors.Is(err, errPanic) {
+			t.Fatalf("EvalLine(%q) panicked: %s", line, err)
+		}
+	})
 }
@@ -25,6 +25,14 @@ type Reader struct {
 	cursor       int
 	lastRune     rune
 	lastRuneSize int
+
+	// positions of the first pending rune, the cursor,
+	// the cursor before the last ReadRune and the start
+	// of the last Emit
+	start   Position
+	pos     Position
+	lastPos Position
+	emitted Position
 }
 
 // NewReader builds a lexer Reader on top of a regular io.Reader
@@ -34,11 +42,25 @@ func NewReader(rd io.Reader) *Reader {
 	}
 
 	return &Reader{
-		buf: make([]byte, 0, ReadBufferSize),
-		src: rd,
+		buf:   make([]byte, 0, ReadBufferSize),
+		src:   rd,
+		start: Position{1, 1},
+		pos:   Position{1, 1},
 	}
 }
 
+// Start returns the position of the first rune
+// not yet emitted or discarded
+func (b *Reader) Start() Position {
+	return b.start
+}
+
+// EmittedPos returns the position of the first rune
+// returned by the last Emit
+func (b *Reader) EmittedPos() Position {
+	return b.emitted
+}
+
 func (b *Reader) fill(needed int) error {
 	for len(b.buf) < needed {
 		// slice with length needed
@@ -63,6 +85,10 @@ func (b *Reader) fill(needed int) error {
 
 		// If we hit EOF, stop fill
 		if errors.Is(err, io.EOF) {
+			if len(b.buf) < needed {
+				// and tell there is nothing more to read
+				return io.EOF
+			}
 			break
 		}
 	}
@@ -106,6 +132,8 @@ func (b *Reader) ReadRune() (rune, int, error) {
 	// remember result for UnreadRune before returning
 	b.lastRune = r
 	b.lastRuneSize = l
+	b.lastPos = b.pos
+	b.pos = b.pos.advance(r)
 
 	return r, l, nil
 }
@@ -123,6 +151,7 @@ func (b *Reader) UnreadRune() error {
 
 		// unread
 		b.cursor = cursor
+		b.pos = b.lastPos
 		// and make sure we don't unread it again
 		b.lastRune = 0
 		b.lastRuneSize = 0
@@ -158,6 +187,14 @@ func (b *Reader) DiscardBytes(count int) error {
 		// count is invalid
 		return errors.New("invalid skip count")
 	default:
+		// move past the extra runes
+		for extra := b.buf[b.cursor : b.cursor+count]; len(extra) > 0; {
+			r, l := utf8.DecodeRune(extra)
+			b.pos = b.pos.advance(r)
+			extra = extra[l:]
+		}
+		b.start = b.pos
+
 		// discard everything before b.cursor+count
 		copy(b.buf, b.buf[b.cursor+count:])
 		b.buf = b.buf[:len(b.buf)-b.cursor-count]
@@ -175,6 +212,7 @@ func (b *Reader) DiscardBytes(count int) error {
 func (b *Reader) Emit() []byte {
 	s := make([]byte, b.cursor)
 	copy(s, b.buf[:b.cursor])
+	b.emitted = b.start
 	b.DiscardBytes(0)
 	return s
 }
@@ -186,6 +224,21 @@ func (b *Reader) EmitString() string {
 	return string(b.Emit())
 }
 
+// HasPrefix tells if the runes after the cursor start
+// with the given string, without consuming them.
+// It only reads as far as the first difference
+func (b *Reader) HasPrefix(prefix string) bool {
+	for i := 0; i < len(prefix); i++ {
+		if err := b.needsBytes(i + 1); err != nil {
+			return false
+		}
+		if b.cursor+i >= len(b.buf) || b.buf[b.cursor+i] != prefix[i] {
+			return false
+		}
+	}
+	return true
+}
+
 // Accept consumes a rune if it's accepted by the provided
 // match function
 func (b *Reader) Accept(match func(rune) bool) bool {
@@ -15,12 +15,18 @@ const (
 	Identifier
 	Integer
 	Float
+	Decimal
 	Operator
 	Separator
 	Boolean
+	Null
 	String
 	LeftParen
 	RightParen
+	LeftBracket
+	RightBracket
+	LeftBrace
+	RightBrace
 	EOL
 	EOF
 )
@@ -36,18 +42,30 @@ func (t TokenType) String() string {
 		return "Integer"
 	case Float:
 		return "Float"
+	case Decimal:
+		return "Decimal"
 	case Operator:
 		return "Operator"
 	case Separator:
 		return "Separator"
 	case Boolean:
 		return "Boolean"
+	case Null:
+		return "Null"
 	case String:
 		return "String"
 	case LeftParen:
 		return "LeftParen"
 	case RightParen:
 		return "RightParen"
+	case LeftBracket:
+		return "LeftBracket"
+	case RightBracket:
+		return "RightBracket"
+	case LeftBrace:
+		return "LeftBrace"
+	case RightBrace:
+		return "RightBrace"
 	case EOL:
 		return "EOL"
 	default:
@@ -55,10 +73,35 @@ func (t TokenType) String() string {
 	}
 }
 
+// Position is a location in the source code
+type Position struct {
+	Line   int
+	Column int
+}
+
+// String returns the position as line:column
+func (p Position) String() string {
+	return fmt.Sprintf("%d:%d", p.Line, p.Column)
+}
+
+// IsValid tells if the position has been set
+func (p Position) IsValid() bool {
+	return p.Line > 0
+}
+
+// advance returns the position after the given rune
+func (p Position) advance(r rune) Position {
+	if r == '\n' {
+		return Position{p.Line + 1, 1}
+	}
+	return Position{p.Line, p.Column + 1}
+}
+
 // Token represents our Token
 type Token struct {
 	Type  TokenType
 	Value string
+	Pos   Position
 }
 
 // GoString  does a recursive print
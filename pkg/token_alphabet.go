@@ -8,12 +8,16 @@ import (
 // Alphabet used within JaVaLanche lexer
 const (
 	asciiLetterRunes        = "abcdefghijklmnopqrstuvwxyz"
-	operatorWithSecondRunes = "&|=<>!+-"
-	operatorStartRunes      = operatorWithSecondRunes + "+-*/%:^"
-	punctuationRunes        = "()\n"
+	operatorWithSecondRunes = "&|=<>!+-?."
+	operatorStartRunes      = operatorWithSecondRunes + "+-*/%:^~"
+	rangeOperator           = ".."
+	lambdaOperator          = "=>"
+	resultOperator          = "->"
+	punctuationRunes        = "()[]{},\n"
+	decimalSuffix           = "d"
 )
 
-var keywords = []string{"if", "else", "for", "elif", "end", "print"}
+var keywords = []string{"if", "else", "for", "elif", "end", "print", "match", "case", "const", "type", "fn", "class", "yield", "break", "try", "catch", "finally", "throw"}
 
 // isKeywordRune checks if a given rune is a part of ASCII letter runes,
 func isKeywordRune(r rune) bool {
@@ -40,6 +44,28 @@ func isDigit(r rune) bool {
 	return unicode.IsDigit(r)
 }
 
+// isHexDigit checks for hexadecimal digits
+func isHexDigit(r rune) bool {
+	return strings.ContainsRune("0123456789abcdefABCDEF", r)
+}
+
+// isDigitOrSeparator checks for digits or the `_` digit separator
+func isDigitOrSeparator(r rune) bool {
+	return isDigit(r) || r == '_'
+}
+
+// isHexDigitOrSeparator checks for hexadecimal digits or the `_`
+// digit separator
+func isHexDigitOrSeparator(r rune) bool {
+	return isHexDigit(r) || r == '_'
+}
+
+// isNumberPart checks for runes that can't follow a number
+// without being part of it
+func isNumberPart(r rune) bool {
+	return isIdentifierPart(r) || r == '.'
+}
+
 // isDoubleQuote checks for strings starting with double quotes
 func isDoubleQuote(r rune) bool {
 	return r == '"'
@@ -60,31 +86,36 @@ func isOperatorWithSecond(r rune) bool {
 	return strings.ContainsRune(operatorWithSecondRunes, r)
 }
 
-// isOperatorNeedsSecond handles operator recognition
-func isOperatorNeedsSecond(r rune) bool {
-	switch r {
-	case '&', '|':
-		return true
-	default:
-		return false
-	}
-}
-
 // isOperatorPair handles double operator recognition
 func isOperatorPair(r1 rune, r2 rune) bool {
 	op := string([]rune{r1, r2})
 	switch op {
 	case "==", "<=", ">=", "!=":
 		return true
-	case "&&", "||":
+	case "&&", "||", "<<", ">>":
 		return true
 	case "++", "--":
 		return true
+	case "??", "?.", "..", "=>", "->":
+		return true
 	default:
 		return false
 	}
 }
 
+// operatorThirdRunes returns the runes that can follow a
+// double operator, for ===, !== and ..<
+func operatorThirdRunes(r1 rune, r2 rune) string {
+	switch string([]rune{r1, r2}) {
+	case "==", "!=":
+		return "="
+	case "..":
+		return "<"
+	default:
+		return ""
+	}
+}
+
 // isPunctuation recognies parantheses
 func isPunctuation(r rune) bool {
 	return strings.ContainsRune(punctuationRunes, r)
@@ -100,10 +131,21 @@ func isBooleanString(code string) bool {
 	}
 }
 
-// isLogicalOperatorStrings handles logical operator detection
-func isLogicalOperatorString(code string) bool {
+// isNullString identifies null
+func isNullString(code string) bool {
 	switch code {
-	case "and", "or", "xor":
+	case "null", "nil":
+		return true
+	default:
+		return false
+	}
+}
+
+// isWordOperatorString handles detection of operators
+// written as words
+func isWordOperatorString(code string) bool {
+	switch code {
+	case "and", "or", "xor", "in", "by":
 		return true
 	default:
 		return false
@@ -120,20 +162,44 @@ func isKeyword(code string) bool {
 	return false
 }
 
+// isIdentifier checks if a whole string is a valid
+// variable name
+func isIdentifier(code string) bool {
+	for i, r := range code {
+		if !isIdentifierPart(r) || (i == 0 && !isIdentifierStart(r)) {
+			return false
+		}
+	}
+	return code != "" && !isKeyword(code) &&
+		!isBooleanString(code) && !isNullString(code) && !isWordOperatorString(code)
+}
+
 // isBinaryOperator checks if the strings is a binary operator
 func isBinaryOperator(code string) bool {
 	switch code {
 	case "+", "-", "*", "/", "==", "!=", ">", "<", ">=", "<=", "&&", "||", "^", "=", "and", "or", "%":
 		return true
+	case "&", "|", "xor", "<<", ">>", "===", "!==", "in", "??", "..", "..<", "by":
+		return true
 	default:
 		return false
 	}
 }
 
+// isConditionalOperator checks for the parts of `c ? a : b`
+func isConditionalOperator(code string) bool {
+	return code == "?" || code == ":"
+}
+
+// isMemberOperator checks if the string accesses a field
+func isMemberOperator(code string) bool {
+	return code == "." || code == "?."
+}
+
 // isPrefixUnaryOperator checks if the strings is a prefix unary operator
 func isPrefixUnaryOperator(code string) bool {
 	switch code {
-	case "!", "+", "-":
+	case "!", "+", "-", "~":
 		return true
 	default:
 		return false
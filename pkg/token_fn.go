@@ -18,8 +18,8 @@ func lexText(t *Tokenizer) stateFn {
 		case err != nil:
 			t.emitError(err)
 			return nil
-		case isDigit(r):
-			// number
+		case isDigit(r), r == '.' && !t.reader.HasPrefix(rangeOperator):
+			// number, including .5, or the member operator
 			return lexNumber
 		case isKeywordRune(r):
 			// keyword or identifier
@@ -43,9 +43,9 @@ func lexText(t *Tokenizer) stateFn {
 			// discard
 			t.reader.DiscardBytes(l)
 		default:
-			err := fmt.Errorf("invalid rune: %q", r)
-			t.emitError(err)
-			return nil
+			// skip it, so the next line can still be read
+			t.reader.ReadRune()
+			return lexInvalidToken(t, fmt.Sprintf("invalid rune: %q", r))
 		}
 	}
 }
@@ -138,8 +138,11 @@ func lexEmitKeyword(t *Tokenizer) {
 	case isBooleanString(s):
 		// true or false
 		t.emitValue(Boolean, s)
-	case isLogicalOperatorString(s):
-		// 'and', 'or', 'xor'
+	case isNullString(s):
+		// null or nil
+		t.emitValue(Null, s)
+	case isWordOperatorString(s):
+		// 'and', 'or', 'xor', 'in'
 		t.emitValue(Operator, s)
 	case isKeyword(s):
 		// other keywords
@@ -178,22 +181,92 @@ func lexIdentifier(t *Tokenizer) stateFn {
 	}
 }
 
-// Lexes Number as Int or Float
+// Lexes Number as Int, Float or Decimal
 func lexNumber(t *Tokenizer) stateFn {
-	t.acceptAllFn(isDigit)
-
-	if t.accept(".") {
-		// dot determines we are emitting float
-		t.acceptAllFn(isDigit)
-		t.emitToken(Float)
-	} else {
-		t.emitToken(Integer)
+	typ := Integer
+
+	switch {
+	case t.accept("0") && t.accept("xXoObB"):
+		// prefixed integer, 0x1F, 0o17 or 0b101.
+		// digits are validated by NewIntegerString
+		t.acceptAllFn(isHexDigitOrSeparator)
+	default:
+		// decimal digits, and optionally a fraction
+		digits := t.acceptAllFn(isDigitOrSeparator)
+		if !t.reader.HasPrefix(rangeOperator) && t.accept(".") {
+			if !t.acceptAllFn(isDigitOrSeparator) && !digits {
+				// a dot without digits accesses a field
+				t.emitToken(Operator)
+				return lexText
+			}
+			// dot determines we are emitting float
+			typ = Float
+		}
+
+		if t.accept("eE") {
+			// so does an exponent
+			t.accept("+-")
+			t.acceptAllFn(isDigitOrSeparator)
+			typ = Float
+		}
+
+		if t.accept(decimalSuffix) {
+			// unless it has the decimal suffix
+			typ = Decimal
+		}
+	}
+
+	// a number can't be immediately followed by
+	// letters, digits or another dot, other than
+	// a range like 1..5
+	if !t.reader.HasPrefix(rangeOperator) && t.acceptAllFn(isNumberPart) {
+		return lexInvalidToken(t, "malformed number")
+	}
+
+	// and its digits need to make sense
+	s := t.reader.EmitString()
+	if !isValidNumber(typ, s) {
+		t.emitError(&ErrInvalidToken{
+			Token:  &Token{Type: typ, Value: s, Pos: t.reader.EmittedPos()},
+			Reason: "malformed number",
+		})
+		return lexText
 	}
 
+	t.emitValue(typ, s)
+	return lexText
+}
+
+// lexInvalidToken emits an error for the malformed token
+// being read and continues
+func lexInvalidToken(t *Tokenizer, reason string) stateFn {
+	s := t.reader.EmitString()
+	t.emitError(&ErrInvalidToken{
+		Token:  &Token{Type: Unknown, Value: s, Pos: t.reader.EmittedPos()},
+		Reason: reason,
+	})
 	return lexText
 }
 
-// Lexes left and right parenthesis
+// isValidNumber checks if a numeric literal can be parsed
+func isValidNumber(typ TokenType, s string) bool {
+	var err error
+
+	switch typ {
+	case Integer:
+		if _, err = NewIntegerString(s); err != nil {
+			_, err = NewBigIntegerString(s)
+		}
+	case Float:
+		_, err = NewFloatString(s)
+	case Decimal:
+		_, err = NewDecimalString(s)
+	}
+
+	return err == nil
+}
+
+// Lexes parenthesis, brackets, braces and new lines
 func lexPunctuation(t *Tokenizer) stateFn {
 	// it can't fail because of the previous PeekRune()
 	r, _, _ := t.reader.ReadRune()
@@ -202,11 +275,21 @@ func lexPunctuation(t *Tokenizer) stateFn {
 		t.emitToken(LeftParen)
 	case ')':
 		t.emitToken(RightParen)
+	case '[':
+		t.emitToken(LeftBracket)
+	case ']':
+		t.emitToken(RightBracket)
+	case '{':
+		t.emitToken(LeftBrace)
+	case '}':
+		t.emitToken(RightBrace)
+	case ',':
+		t.emitToken(Separator)
 	case '\n':
 		t.emitToken(EOL)
 	default:
-		// can't happen. we know it satisfies isPunctionation()
-		panic("unreachable")
+		// isPunctuation() and this switch disagree
+		return lexInvalidToken(t, fmt.Sprintf("unexpected rune: %q", r))
 	}
 
 	return lexText
@@ -226,12 +309,8 @@ func lexOperator(t *Tokenizer) stateFn {
 			t.emitError(err)
 			return nil
 		case isOperatorPair(r1, r2):
-			// good pair
-		case isOperatorNeedsSecond(r1):
-			// doesn't work without a second, non-fatal
-			err := fmt.Errorf("%q: %s", r1+r2, "invalid operator")
-			t.emitError(err)
-			return lexText
+			// good pair, maybe a triple
+			t.accept(operatorThirdRunes(r1, r2))
 		default:
 			// r2 isn't part of the op, emit without
 			if err = t.reader.UnreadRune(); err != nil {
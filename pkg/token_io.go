@@ -85,12 +85,14 @@ func (t *Tokenizer) emitError(err error) {
 	}
 }
 
-// emitValue emits value of the toen and type
+// emitValue emits value of the toen and type, at the
+// position of the last emitted runes
 func (t *Tokenizer) emitValue(typ TokenType, val string) {
 	res := &TokenResult{
 		Token: &Token{
 			Type:  typ,
 			Value: val,
+			Pos:   t.reader.EmittedPos(),
 		},
 	}
 